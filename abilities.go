@@ -0,0 +1,451 @@
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Ability is one of the six ability scores of a character.
+type Ability int
+
+const (
+	_ Ability = iota
+	Strength
+	Dexterity
+	Constitution
+	Intelligence
+	Wisdom
+	Charisma
+)
+
+// Abilities are the six abilities, in the order they are generated.
+var Abilities = []Ability{Strength, Dexterity, Constitution, Intelligence, Wisdom, Charisma}
+
+// StandardArrayScores are the scores assigned to the abilities when using the standard array.
+var StandardArrayScores = []int{15, 14, 13, 12, 10, 8}
+
+// DefaultPointBuyCosts is the cost of each ability score when using point buy.
+var DefaultPointBuyCosts = map[int]int{
+	8:  0,
+	9:  1,
+	10: 2,
+	11: 3,
+	12: 4,
+	13: 5,
+	14: 7,
+	15: 9,
+}
+
+const (
+	DefaultPointBuyBudget = 27  // The number of points that may be spent when using point buy
+	MaxAbilityRerolls     = 100 // The maximum number of times a set of ability scores is re-rolled
+)
+
+var (
+	ErrInvalidAbilities = errors.New("each of the six abilities must be assigned exactly once")
+	ErrInvalidScore     = errors.New("ability score cannot be bought")
+	ErrOverBudget       = errors.New("ability scores cost more than the point buy budget")
+)
+
+// AbilityScore is the generated score for a single ability.
+type AbilityScore interface {
+	Ability() Ability // The ability the score is for
+	Score() int       // The value of the ability score
+	Modifier() int    // The ability modifier derived from the score
+	Rolls() []Roll    // The dice rolled for the score; the dropped dice are not included
+	Dropped() []Roll  // The dice that were rolled but not included in the score
+	fmt.Stringer      // String representation of the ability score
+}
+
+// AbilityScores is a set of generated scores for the six abilities.
+type AbilityScores interface {
+	Scores() []AbilityScore       // The scores for each ability, in the order of Abilities
+	Score(a Ability) AbilityScore // The score for the ability
+	Total() int                   // The total of all ability scores
+	TotalModifier() int           // The total of all ability modifiers
+	Transcript() string           // A transcript of how the scores were generated, including any re-rolled sets
+	fmt.Stringer                  // String representation of the ability scores
+}
+
+// abilityScore is an implementation of the AbilityScore interface.
+type abilityScore struct {
+	ability Ability // The ability the score is for
+	score   int     // The value of the ability score
+	rolls   []Roll  // The dice rolled for the score that were kept
+	dropped []Roll  // The dice rolled for the score that were dropped
+	rerolls []Roll  // The dice that were re-rolled because they rolled a 1
+}
+
+// abilityScores is an implementation of the AbilityScores interface.
+type abilityScores struct {
+	scores     []*abilityScore // The scores for each ability
+	transcript []string        // The transcript of how the scores were generated
+}
+
+// abilityGenerator holds the policies used when rolling ability scores.
+type abilityGenerator struct {
+	rerollOnes  bool                     // If true, any dice that rolls a 1 is re-rolled
+	rerollSetIf func(AbilityScores) bool // If set, returns true if a set of ability scores should be re-rolled
+}
+
+// AbilityScoreOption is a function that can modify the policies used when rolling ability scores.
+type AbilityScoreOption func(*abilityGenerator)
+
+// Roll4d6DropLowest rolls four D6 for each ability, dropping the lowest dice.
+func Roll4d6DropLowest(opts ...AbilityScoreOption) AbilityScores {
+	return newAbilityGenerator(opts...).generate("4d6 drop lowest", 4, 3)
+}
+
+// Roll3d6InOrder rolls three D6 for each ability, assigned in the order of Abilities.
+func Roll3d6InOrder(opts ...AbilityScoreOption) AbilityScores {
+	return newAbilityGenerator(opts...).generate("3d6 in order", 3, 3)
+}
+
+// WithRerollOnes re-rolls any dice that rolls a 1 until it rolls a higher value.
+func WithRerollOnes() AbilityScoreOption {
+	return func(g *abilityGenerator) {
+		g.rerollOnes = true
+	}
+}
+
+// WithRerollSetIf re-rolls the entire set of ability scores while the provided function returns true,
+// up to MaxAbilityRerolls times.
+func WithRerollSetIf(reroll func(AbilityScores) bool) AbilityScoreOption {
+	return func(g *abilityGenerator) {
+		g.rerollSetIf = reroll
+	}
+}
+
+// WithMinimumTotalModifier re-rolls the entire set of ability scores if the total of the ability
+// modifiers is less than the minimum.
+func WithMinimumTotalModifier(minimum int) AbilityScoreOption {
+	return WithRerollSetIf(func(scores AbilityScores) bool {
+		return scores.TotalModifier() < minimum
+	})
+}
+
+// StandardArray assigns StandardArrayScores to the abilities in the order provided. If no abilities
+// are provided, the scores are assigned in the order of Abilities.
+func StandardArray(order ...Ability) (AbilityScores, error) {
+	if len(order) == 0 {
+		order = Abilities
+	}
+	if !isAbilityPermutation(order) {
+		return nil, ErrInvalidAbilities
+	}
+
+	scores := make(map[Ability]int, len(order))
+	for i, ability := range order {
+		scores[ability] = StandardArrayScores[i]
+	}
+
+	as := newAbilityScores(scores)
+	as.transcript = append(as.transcript, "Standard array: "+as.String())
+	return as, nil
+}
+
+// PointBuy validates the ability scores against the point buy costs and budget, returning them
+// as a set of ability scores. If no costs are provided, DefaultPointBuyCosts is used.
+func PointBuy(scores map[Ability]int, budget int, costs map[int]int) (AbilityScores, error) {
+	cost, err := PointBuyCost(scores, costs)
+	if err != nil {
+		return nil, err
+	}
+	if cost > budget {
+		return nil, fmt.Errorf("%w: %d points spent, %d available", ErrOverBudget, cost, budget)
+	}
+
+	as := newAbilityScores(scores)
+	as.transcript = append(as.transcript, "Point buy ("+strconv.Itoa(cost)+"/"+strconv.Itoa(budget)+" points): "+as.String())
+	return as, nil
+}
+
+// PointBuyCost returns the number of points needed to buy the ability scores. If no costs are
+// provided, DefaultPointBuyCosts is used.
+func PointBuyCost(scores map[Ability]int, costs map[int]int) (int, error) {
+	if costs == nil {
+		costs = DefaultPointBuyCosts
+	}
+	if len(scores) != len(Abilities) {
+		return 0, ErrInvalidAbilities
+	}
+
+	var total int
+	for _, ability := range Abilities {
+		score, ok := scores[ability]
+		if !ok {
+			return 0, ErrInvalidAbilities
+		}
+		cost, ok := costs[score]
+		if !ok {
+			return 0, fmt.Errorf("%w: %s %d", ErrInvalidScore, ability, score)
+		}
+		total += cost
+	}
+
+	return total, nil
+}
+
+// AbilityModifier returns the ability modifier for an ability score.
+func AbilityModifier(score int) int {
+	// Round down, including for negative values
+	if score < 10 {
+		return (score - 11) / 2
+	}
+	return (score - 10) / 2
+}
+
+// newAbilityGenerator creates a generator with the policies applied.
+func newAbilityGenerator(opts ...AbilityScoreOption) *abilityGenerator {
+	g := &abilityGenerator{}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// generate rolls a set of ability scores, keeping the highest dice for each ability. The set is
+// re-rolled if the generator's re-roll policy requires it.
+func (g *abilityGenerator) generate(method string, numDice int, keep int) AbilityScores {
+	transcript := make([]string, 0, 1)
+	for attempt := 1; ; attempt++ {
+		as := &abilityScores{
+			scores: make([]*abilityScore, 0, len(Abilities)),
+		}
+		for _, ability := range Abilities {
+			as.scores = append(as.scores, g.rollScore(ability, numDice, keep))
+		}
+
+		line := "Set " + strconv.Itoa(attempt) + " (" + method + "): " + as.String()
+		if g.rerollSetIf != nil && attempt <= MaxAbilityRerolls && g.rerollSetIf(as) {
+			transcript = append(transcript, line+" - rerolled")
+			continue
+		}
+		as.transcript = append(transcript, line)
+		return as
+	}
+}
+
+// rollScore rolls the dice for a single ability, keeping the highest dice.
+func (g *abilityGenerator) rollScore(ability Ability, numDice int, keep int) *abilityScore {
+	as := &abilityScore{
+		ability: ability,
+	}
+
+	rolls := make([]Roll, 0, numDice)
+	for range numDice {
+		r := D6.Roll()
+		for g.rerollOnes && r.Value() == 1 {
+			as.rerolls = append(as.rerolls, r)
+			r = D6.Roll()
+		}
+		rolls = append(rolls, r)
+	}
+
+	// Keep the highest dice, in the order they were rolled
+	sorted := make([]Roll, len(rolls))
+	copy(sorted, rolls)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Value() < sorted[j].Value()
+	})
+	drop := sorted[:len(sorted)-keep]
+	for _, r := range rolls {
+		if i := indexOfRoll(drop, r); i >= 0 {
+			as.dropped = append(as.dropped, r)
+			drop = append(drop[:i], drop[i+1:]...)
+			continue
+		}
+		as.rolls = append(as.rolls, r)
+		as.score += r.Value()
+	}
+
+	return as
+}
+
+// indexOfRoll returns the index of the roll in the slice, or -1 if it is not included.
+func indexOfRoll(rolls []Roll, r Roll) int {
+	for i, roll := range rolls {
+		if roll == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// isAbilityPermutation returns true if each of the six abilities is included exactly once.
+func isAbilityPermutation(abilities []Ability) bool {
+	if len(abilities) != len(Abilities) {
+		return false
+	}
+	seen := make(map[Ability]bool, len(abilities))
+	for _, ability := range abilities {
+		if ability < Strength || ability > Charisma || seen[ability] {
+			return false
+		}
+		seen[ability] = true
+	}
+	return true
+}
+
+// newAbilityScores creates a set of ability scores that were not rolled.
+func newAbilityScores(scores map[Ability]int) *abilityScores {
+	as := &abilityScores{
+		scores: make([]*abilityScore, 0, len(Abilities)),
+	}
+	for _, ability := range Abilities {
+		as.scores = append(as.scores, &abilityScore{
+			ability: ability,
+			score:   scores[ability],
+		})
+	}
+	return as
+}
+
+// Ability returns the ability the score is for.
+func (as *abilityScore) Ability() Ability {
+	return as.ability
+}
+
+// Score returns the value of the ability score.
+func (as *abilityScore) Score() int {
+	return as.score
+}
+
+// Modifier returns the ability modifier derived from the score.
+func (as *abilityScore) Modifier() int {
+	return AbilityModifier(as.score)
+}
+
+// Rolls returns the dice rolled for the score that were kept.
+func (as *abilityScore) Rolls() []Roll {
+	return as.rolls
+}
+
+// Dropped returns the dice rolled for the score that were dropped.
+func (as *abilityScore) Dropped() []Roll {
+	return as.dropped
+}
+
+// Scores returns the scores for each ability, in the order of Abilities.
+func (as *abilityScores) Scores() []AbilityScore {
+	scores := make([]AbilityScore, 0, len(as.scores))
+	for _, score := range as.scores {
+		scores = append(scores, score)
+	}
+	return scores
+}
+
+// Score returns the score for the ability, or nil if it is not a valid ability.
+func (as *abilityScores) Score(a Ability) AbilityScore {
+	for _, score := range as.scores {
+		if score.ability == a {
+			return score
+		}
+	}
+	return nil
+}
+
+// Total returns the total of all ability scores.
+func (as *abilityScores) Total() int {
+	var total int
+	for _, score := range as.scores {
+		total += score.score
+	}
+	return total
+}
+
+// TotalModifier returns the total of all ability modifiers.
+func (as *abilityScores) TotalModifier() int {
+	var total int
+	for _, score := range as.scores {
+		total += score.Modifier()
+	}
+	return total
+}
+
+// Transcript returns a transcript of how the scores were generated, with one line for each set of
+// scores that was generated.
+func (as *abilityScores) Transcript() string {
+	return strings.Join(as.transcript, "\n")
+}
+
+// String returns the abbreviation for the ability.
+func (a Ability) String() string {
+	switch a {
+	case Strength:
+		return "STR"
+	case Dexterity:
+		return "DEX"
+	case Constitution:
+		return "CON"
+	case Intelligence:
+		return "INT"
+	case Wisdom:
+		return "WIS"
+	case Charisma:
+		return "CHA"
+	default:
+		return "Unknown"
+	}
+}
+
+// String returns a string representation of the ability score, such as `STR 15 (+2)`. If the score
+// was rolled, the dice are included, with any dropped dice in brackets and re-rolled 1s noted.
+func (as *abilityScore) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(as.ability.String())
+	sb.WriteString(" ")
+	sb.WriteString(strconv.Itoa(as.score))
+	sb.WriteString(" (")
+	sb.WriteString(formatModifier(as.Modifier()))
+	if len(as.rolls) > 0 {
+		sb.WriteString(": ")
+		for i, r := range as.rolls {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(strconv.Itoa(r.Value()))
+		}
+		for _, r := range as.dropped {
+			sb.WriteString(", [")
+			sb.WriteString(strconv.Itoa(r.Value()))
+			sb.WriteString("]")
+		}
+		if len(as.rerolls) > 0 {
+			sb.WriteString(", ")
+			sb.WriteString(strconv.Itoa(len(as.rerolls)))
+			sb.WriteString(" rerolled")
+		}
+	}
+	sb.WriteString(")")
+
+	return sb.String()
+}
+
+// String returns a string representation of the ability scores, including the total modifier.
+func (as *abilityScores) String() string {
+	var sb strings.Builder
+
+	for i, score := range as.scores {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(score.String())
+	}
+	sb.WriteString("; total modifier ")
+	sb.WriteString(formatModifier(as.TotalModifier()))
+
+	return sb.String()
+}
+
+// formatModifier returns a modifier with a leading sign, such as `+2` or `-1`.
+func formatModifier(modifier int) string {
+	if modifier < 0 {
+		return strconv.Itoa(modifier)
+	}
+	return "+" + strconv.Itoa(modifier)
+}
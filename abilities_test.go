@@ -0,0 +1,194 @@
+package dice
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestAbilityModifier tests calculating the modifier for an ability score
+func TestAbilityModifier(t *testing.T) {
+	tests := []struct {
+		score    int
+		expected int
+	}{
+		{1, -5},
+		{7, -2},
+		{8, -1},
+		{9, -1},
+		{10, 0},
+		{11, 0},
+		{12, 1},
+		{15, 2},
+		{18, 4},
+		{20, 5},
+	}
+
+	for _, test := range tests {
+		if modifier := AbilityModifier(test.score); modifier != test.expected {
+			t.Errorf("AbilityModifier(%d) = %d; expected %d", test.score, modifier, test.expected)
+		}
+	}
+}
+
+// TestRoll4d6DropLowest tests rolling ability scores with 4d6, dropping the lowest dice
+func TestRoll4d6DropLowest(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		scores := Roll4d6DropLowest()
+		if len(scores.Scores()) != len(Abilities) {
+			t.Fatalf("Expected %d scores, got %d", len(Abilities), len(scores.Scores()))
+		}
+
+		for j, score := range scores.Scores() {
+			if score.Ability() != Abilities[j] {
+				t.Errorf("Expected score %d to be for %s, got %s", j, Abilities[j], score.Ability())
+			}
+			if len(score.Rolls()) != 3 || len(score.Dropped()) != 1 {
+				t.Fatalf("Expected 3 kept and 1 dropped dice, got %s", score)
+			}
+
+			var total int
+			for _, r := range score.Rolls() {
+				total += r.Value()
+				if r.Value() < score.Dropped()[0].Value() {
+					t.Errorf("Expected the lowest dice to be dropped, got %s", score)
+				}
+			}
+			if score.Score() != total {
+				t.Errorf("Expected score to be %d, got %d", total, score.Score())
+			}
+			if score.Modifier() != AbilityModifier(total) {
+				t.Errorf("Expected modifier to be %d, got %d", AbilityModifier(total), score.Modifier())
+			}
+		}
+	}
+}
+
+// TestRoll3d6InOrder tests rolling ability scores with 3d6 in order
+func TestRoll3d6InOrder(t *testing.T) {
+	scores := Roll3d6InOrder()
+	for _, score := range scores.Scores() {
+		if len(score.Rolls()) != 3 || len(score.Dropped()) != 0 {
+			t.Errorf("Expected 3 kept dice, got %s", score)
+		}
+		if score.Score() < 3 || score.Score() > 18 {
+			t.Errorf("Expected score between 3 and 18, got %d", score.Score())
+		}
+	}
+	if scores.Score(Wisdom) != scores.Scores()[4] {
+		t.Errorf("Expected Score(Wisdom) to return the fifth score")
+	}
+	if !strings.HasPrefix(scores.Transcript(), "Set 1 (3d6 in order): STR ") {
+		t.Errorf("Unexpected transcript: %s", scores.Transcript())
+	}
+}
+
+// TestAbilityScoreRerollPolicies tests the policies for re-rolling ability scores
+func TestAbilityScoreRerollPolicies(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		scores := Roll4d6DropLowest(WithRerollOnes())
+		for _, score := range scores.Scores() {
+			for _, r := range append(score.Rolls(), score.Dropped()...) {
+				if r.Value() == 1 {
+					t.Errorf("Expected 1s to be re-rolled, got %s", score)
+				}
+			}
+		}
+
+		scores = Roll3d6InOrder(WithMinimumTotalModifier(3))
+		if scores.TotalModifier() < 3 {
+			t.Errorf("Expected total modifier of at least +3, got %s", scores)
+		}
+	}
+
+	// Once the maximum number of re-rolls is reached, the last set is kept
+	scores := Roll3d6InOrder(WithRerollSetIf(func(AbilityScores) bool { return true }))
+	lines := strings.Split(scores.Transcript(), "\n")
+	if len(lines) != MaxAbilityRerolls+1 {
+		t.Errorf("Expected %d sets in the transcript, got %d", MaxAbilityRerolls+1, len(lines))
+	}
+	if !strings.HasSuffix(lines[0], " - rerolled") || strings.HasSuffix(lines[len(lines)-1], " - rerolled") {
+		t.Errorf("Expected all but the last set to be re-rolled")
+	}
+}
+
+// TestStandardArray tests assigning the standard array to the abilities
+func TestStandardArray(t *testing.T) {
+	scores, err := StandardArray()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if scores.Score(Strength).Score() != 15 || scores.Score(Charisma).Score() != 8 {
+		t.Errorf("Expected standard array in order, got %s", scores)
+	}
+	if scores.Total() != 72 {
+		t.Errorf("Expected total of 72, got %d", scores.Total())
+	}
+
+	scores, err = StandardArray(Dexterity, Constitution, Wisdom, Charisma, Intelligence, Strength)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "STR 8 (-1), DEX 15 (+2), CON 14 (+2), INT 10 (+0), WIS 13 (+1), CHA 12 (+1); total modifier +5"
+	if scores.String() != expected {
+		t.Errorf("Expected `%s`, got `%s`", expected, scores.String())
+	}
+
+	_, err = StandardArray(Dexterity, Dexterity, Wisdom, Charisma, Intelligence, Strength)
+	if !errors.Is(err, ErrInvalidAbilities) {
+		t.Errorf("Expected ErrInvalidAbilities for a repeated ability, got %v", err)
+	}
+	_, err = StandardArray(Dexterity)
+	if !errors.Is(err, ErrInvalidAbilities) {
+		t.Errorf("Expected ErrInvalidAbilities for missing abilities, got %v", err)
+	}
+}
+
+// TestPointBuy tests validating and costing ability scores using point buy
+func TestPointBuy(t *testing.T) {
+	scores := map[Ability]int{
+		Strength:     15,
+		Dexterity:    14,
+		Constitution: 13,
+		Intelligence: 12,
+		Wisdom:       10,
+		Charisma:     8,
+	}
+
+	cost, err := PointBuyCost(scores, nil)
+	if err != nil || cost != 27 {
+		t.Errorf("Expected cost of 27, got %d (%v)", cost, err)
+	}
+	as, err := PointBuy(scores, DefaultPointBuyBudget, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if as.Score(Dexterity).Score() != 14 {
+		t.Errorf("Expected DEX 14, got %s", as.Score(Dexterity))
+	}
+
+	_, err = PointBuy(scores, 26, nil)
+	if !errors.Is(err, ErrOverBudget) {
+		t.Errorf("Expected ErrOverBudget, got %v", err)
+	}
+
+	scores[Strength] = 16
+	_, err = PointBuyCost(scores, nil)
+	if !errors.Is(err, ErrInvalidScore) {
+		t.Errorf("Expected ErrInvalidScore, got %v", err)
+	}
+
+	// A custom cost table allows other scores
+	costs := map[int]int{8: 0, 10: 1, 12: 2, 14: 3, 16: 4, 18: 5}
+	scores = map[Ability]int{Strength: 16, Dexterity: 14, Constitution: 12, Intelligence: 10, Wisdom: 8, Charisma: 8}
+	cost, err = PointBuyCost(scores, costs)
+	if err != nil || cost != 10 {
+		t.Errorf("Expected cost of 10, got %d (%v)", cost, err)
+	}
+
+	delete(scores, Charisma)
+	_, err = PointBuyCost(scores, costs)
+	if !errors.Is(err, ErrInvalidAbilities) {
+		t.Errorf("Expected ErrInvalidAbilities, got %v", err)
+	}
+}
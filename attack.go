@@ -0,0 +1,334 @@
+package dice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Attack is an attack made with a to-hit dice against a target's armor class, dealing damage on a hit.
+type Attack interface {
+	Name() string                                                // The name of the attack
+	ToHit() Dice                                                 // The dice rolled to hit the target
+	Damage() Dice                                                // The dice rolled for damage on a hit
+	CriticalDamage() CriticalDamageRule                          // The rule used to roll damage on a critical hit
+	Resolve(ac DifficultyClass, opts ...RollOption) AttackResult // Rolls the attack against the armor class
+	fmt.Stringer                                                 // String representation of the attack
+}
+
+// AttackResult is the result of resolving an attack against an armor class.
+type AttackResult interface {
+	Attack() Attack              // The attack that was resolved
+	ArmorClass() DifficultyClass // The armor class the attack was made against
+	ToHitRoll() Roll             // The roll to hit the target
+	Hit() bool                   // Returns true if the attack hit the target
+	IsCriticalHit() bool         // Returns true if the attack was a critical hit
+	IsCriticalMiss() bool        // Returns true if the attack was a critical miss
+	DamageRoll() Roll            // The damage roll, or nil if the attack missed
+	Damage() int                 // The damage dealt by the attack, which is zero if it missed
+	fmt.Stringer                 // Description of the attack and its outcome
+}
+
+// Multiattack is a sequence of attacks made against the same target.
+type Multiattack interface {
+	Attacks() []Attack                                                // The attacks in the sequence
+	Resolve(ac DifficultyClass, opts ...RollOption) MultiattackResult // Rolls each attack against the armor class
+	fmt.Stringer                                                      // String representation of the multiattack
+}
+
+// MultiattackResult is the result of resolving a sequence of attacks against an armor class.
+type MultiattackResult interface {
+	Results() []AttackResult // The result of each attack, in the order they were made
+	Hits() int               // The number of attacks that hit the target
+	Damage() int             // The total damage dealt by the attacks
+	fmt.Stringer             // Description of each attack and the total damage
+}
+
+// attack is an implementation of the Attack interface.
+type attack struct {
+	name           string             // The name of the attack
+	toHit          Dice               // The dice rolled to hit the target
+	damage         Dice               // The dice rolled for damage on a hit
+	criticalDamage CriticalDamageRule // The rule used to roll damage on a critical hit
+	opts           []RollOption       // The options used when rolling to hit
+}
+
+// attackResult is an implementation of the AttackResult interface.
+type attackResult struct {
+	attack     *attack         // The attack that was resolved
+	ac         DifficultyClass // The armor class the attack was made against
+	toHitRoll  Roll            // The roll to hit the target
+	hit        bool            // If true, the attack hit the target
+	damageRoll Roll            // The damage roll, if the attack hit
+}
+
+// multiattack is an implementation of the Multiattack interface.
+type multiattack []Attack
+
+// multiattackResult is an implementation of the MultiattackResult interface.
+type multiattackResult []AttackResult
+
+// AttackOption is a function that can modify the default values of an attack.
+type AttackOption func(*attack)
+
+// NewAttack creates an attack that rolls the to-hit dice against an armor class and the damage dice on a
+// hit. A natural 20 on the d20 is a critical hit and a natural 1 is a critical miss. Critical hits roll
+// twice the damage dice unless another rule is set with WithAttackCriticalDamage. A to-hit dice such as
+// `1d20+5` is rolled as a d20 plus a constant so that critical hits can be detected.
+func NewAttack(toHit Dice, damage Dice, opts ...AttackOption) Attack {
+	a := &attack{
+		toHit:          attackToHitDice(toHit),
+		damage:         damage,
+		criticalDamage: CriticalDoubleDice,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// WithAttackName sets the name of the attack, which is used in the description of the attack.
+func WithAttackName(name string) AttackOption {
+	return func(a *attack) {
+		a.name = name
+	}
+}
+
+// WithAttackCriticalDamage sets the rule used to roll damage on a critical hit.
+func WithAttackCriticalDamage(rule CriticalDamageRule) AttackOption {
+	return func(a *attack) {
+		a.criticalDamage = rule
+	}
+}
+
+// WithAttackRollOptions sets the options that are always used when rolling to hit, such as
+// WithCriticalHit(19) for an expanded critical range.
+func WithAttackRollOptions(opts ...RollOption) AttackOption {
+	return func(a *attack) {
+		a.opts = append(a.opts, opts...)
+	}
+}
+
+// NewMultiattack creates a sequence of attacks that are made against the same target.
+func NewMultiattack(attacks ...Attack) Multiattack {
+	return multiattack(attacks)
+}
+
+// attackToHitDice returns the dice used to roll to hit. A single d20 with a modifier is split into a
+// d20 and a constant, as critical hits are only detected on a d20 without a modifier. The dice rolled
+// by preset dice are split in the same way, keeping the preset options. Saving throws are split in the
+// same way.
+func attackToHitDice(d Dice) Dice {
+	if pd, ok := d.(*presetDice); ok {
+		return NewPresetDice(attackToHitDice(pd.Dice), pd.opts...)
+	}
+	dd, ok := d.(*dice)
+	if !ok || dd.numDice != 1 || dd.numSides != 20 || dd.modifier == 0 || dd.isDebuff {
+		return d
+	}
+	return NewDiceSet(dd.Customize(WithModifier(0), WithSource(dd.source)), NewConstant(dd.modifier))
+}
+
+// Name returns the name of the attack.
+func (a *attack) Name() string {
+	return a.name
+}
+
+// ToHit returns the dice rolled to hit the target.
+func (a *attack) ToHit() Dice {
+	return a.toHit
+}
+
+// Damage returns the dice rolled for damage on a hit.
+func (a *attack) Damage() Dice {
+	return a.damage
+}
+
+// CriticalDamage returns the rule used to roll damage on a critical hit.
+func (a *attack) CriticalDamage() CriticalDamageRule {
+	return a.criticalDamage
+}
+
+// Resolve rolls the attack against the armor class. The options, such as WithAdvantage or
+// WithCriticalHit, are applied to the roll to hit after those set on the attack. The damage is only
+// rolled if the attack hits.
+func (a *attack) Resolve(ac DifficultyClass, opts ...RollOption) AttackResult {
+	rollOpts := make([]RollOption, 0, len(a.opts)+len(opts)+1)
+	rollOpts = append(rollOpts, WithCriticalHitAllowed())
+	rollOpts = append(rollOpts, a.opts...)
+	rollOpts = append(rollOpts, opts...)
+
+	ar := &attackResult{
+		attack:    a,
+		ac:        ac,
+		toHitRoll: a.toHit.Roll(rollOpts...),
+	}
+	ar.hit = ac.Check(ar.toHitRoll)
+
+	switch {
+	case !ar.hit:
+		// No damage is rolled on a miss
+	case ar.toHitRoll.IsCriticalHit():
+		ar.damageRoll = a.damage.Roll(WithCriticalDamage(a.criticalDamage))
+	default:
+		ar.damageRoll = a.damage.Roll()
+	}
+
+	return ar
+}
+
+// Attacks returns the attacks in the sequence.
+func (m multiattack) Attacks() []Attack {
+	return m
+}
+
+// Resolve rolls each attack in the sequence against the armor class, using the same options for each
+// roll to hit.
+func (m multiattack) Resolve(ac DifficultyClass, opts ...RollOption) MultiattackResult {
+	results := make(multiattackResult, 0, len(m))
+	for _, a := range m {
+		results = append(results, a.Resolve(ac, opts...))
+	}
+	return results
+}
+
+// Attack returns the attack that was resolved.
+func (ar *attackResult) Attack() Attack {
+	return ar.attack
+}
+
+// ArmorClass returns the armor class the attack was made against.
+func (ar *attackResult) ArmorClass() DifficultyClass {
+	return ar.ac
+}
+
+// ToHitRoll returns the roll to hit the target.
+func (ar *attackResult) ToHitRoll() Roll {
+	return ar.toHitRoll
+}
+
+// Hit returns `true` if the attack hit the target; `false` otherwise
+func (ar *attackResult) Hit() bool {
+	return ar.hit
+}
+
+// IsCriticalHit returns `true` if the attack was a critical hit; `false` otherwise
+func (ar *attackResult) IsCriticalHit() bool {
+	return ar.toHitRoll.IsCriticalHit()
+}
+
+// IsCriticalMiss returns `true` if the attack was a critical miss; `false` otherwise
+func (ar *attackResult) IsCriticalMiss() bool {
+	return ar.toHitRoll.IsCriticalMiss()
+}
+
+// DamageRoll returns the damage roll, or nil if the attack missed.
+func (ar *attackResult) DamageRoll() Roll {
+	return ar.damageRoll
+}
+
+// Damage returns the damage dealt by the attack. The damage is never less than zero.
+func (ar *attackResult) Damage() int {
+	if ar.damageRoll == nil {
+		return 0
+	}
+	return max(ar.damageRoll.Value(), 0)
+}
+
+// Results returns the result of each attack, in the order they were made.
+func (mr multiattackResult) Results() []AttackResult {
+	return mr
+}
+
+// Hits returns the number of attacks that hit the target.
+func (mr multiattackResult) Hits() int {
+	var hits int
+	for _, ar := range mr {
+		if ar.Hit() {
+			hits++
+		}
+	}
+	return hits
+}
+
+// Damage returns the total damage dealt by the attacks.
+func (mr multiattackResult) Damage() int {
+	var damage int
+	for _, ar := range mr {
+		damage += ar.Damage()
+	}
+	return damage
+}
+
+// String returns a string representation of the attack, such as `Longsword: 1d20 + 5, 1d8+3 slashing`.
+func (a *attack) String() string {
+	var sb strings.Builder
+
+	if a.name != "" {
+		sb.WriteString(a.name)
+		sb.WriteString(": ")
+	}
+	sb.WriteString(a.toHit.String())
+	sb.WriteString(" to hit, ")
+	sb.WriteString(a.damage.String())
+	sb.WriteString(" damage")
+
+	return sb.String()
+}
+
+// String returns a string representation of the multiattack, with each attack separated by a semicolon.
+func (m multiattack) String() string {
+	attacks := make([]string, 0, len(m))
+	for _, a := range m {
+		attacks = append(attacks, a.String())
+	}
+	return strings.Join(attacks, "; ")
+}
+
+// String returns a description of the attack and its outcome, such as
+// `Longsword: 14 (1d20) + 5 = 19 vs AC 15: Hit for 7 (1d8 slashing) + 3 = 10 damage`.
+func (ar *attackResult) String() string {
+	var sb strings.Builder
+
+	if ar.attack.name != "" {
+		sb.WriteString(ar.attack.name)
+		sb.WriteString(": ")
+	}
+	sb.WriteString(ar.toHitRoll.String())
+	sb.WriteString(" vs AC ")
+	sb.WriteString(ar.ac.String())
+	sb.WriteString(": ")
+	switch {
+	case ar.IsCriticalHit():
+		sb.WriteString("Critical Hit")
+	case ar.hit:
+		sb.WriteString("Hit")
+	case ar.IsCriticalMiss():
+		sb.WriteString("Critical Miss")
+	default:
+		sb.WriteString("Miss")
+	}
+	if ar.damageRoll != nil {
+		sb.WriteString(" for ")
+		sb.WriteString(ar.damageRoll.String())
+		sb.WriteString(" damage")
+	}
+
+	return sb.String()
+}
+
+// String returns a description of each attack on a separate line, followed by the total damage.
+func (mr multiattackResult) String() string {
+	var sb strings.Builder
+
+	for _, ar := range mr {
+		sb.WriteString(ar.String())
+		sb.WriteString("\n")
+	}
+	sb.WriteString("Total: ")
+	sb.WriteString(strconv.Itoa(mr.Damage()))
+	sb.WriteString(" damage")
+
+	return sb.String()
+}
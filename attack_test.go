@@ -0,0 +1,187 @@
+package dice
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestAttackToHitDice tests splitting a d20 with a modifier into a d20 and a constant
+func TestAttackToHitDice(t *testing.T) {
+	a := NewAttack(ParseDice("1d20+5"), ParseDice("1d8+3"))
+	if a.ToHit().String() != "1d20 + 5" {
+		t.Errorf("Expected to-hit dice `1d20 + 5`, got `%s`", a.ToHit())
+	}
+	if len(a.ToHit().GetDice()) != 2 {
+		t.Errorf("Expected to-hit dice to have 2 dice, got %d", len(a.ToHit().GetDice()))
+	}
+
+	a = NewAttack(D20, ParseDice("1d8+3"))
+	if a.ToHit() != D20 {
+		t.Errorf("Expected to-hit dice to be unchanged, got `%s`", a.ToHit())
+	}
+	if a.CriticalDamage() != CriticalDoubleDice {
+		t.Errorf("Expected default critical damage rule to be %s, got %s", CriticalDoubleDice, a.CriticalDamage())
+	}
+}
+
+// TestAttackResolve tests resolving an attack against an armor class
+func TestAttackResolve(t *testing.T) {
+	a := NewAttack(ParseDice("1d20+5"), ParseDice("1d8+3"), WithAttackName("Longsword"))
+	ac := NewDifficultyClass(15)
+
+	for i := 0; i < 200; i++ {
+		result := a.Resolve(ac)
+		toHit := result.ToHitRoll()
+		natural := toHit.GetAllRolls()[0].Value()
+
+		switch {
+		case natural == 20:
+			if !result.Hit() || !result.IsCriticalHit() {
+				t.Errorf("Expected a natural 20 to be a critical hit, got %s", result)
+			}
+			if damage := result.Damage(); damage < 5 || damage > 19 {
+				t.Errorf("Expected critical damage between 5 and 19, got %s", result)
+			}
+		case natural == 1:
+			if result.Hit() || !result.IsCriticalMiss() {
+				t.Errorf("Expected a natural 1 to be a critical miss, got %s", result)
+			}
+		case toHit.Value() >= 15:
+			if !result.Hit() || result.IsCriticalHit() {
+				t.Errorf("Expected a hit, got %s", result)
+			}
+			if damage := result.Damage(); damage < 4 || damage > 11 {
+				t.Errorf("Expected damage between 4 and 11, got %s", result)
+			}
+		default:
+			if result.Hit() || result.DamageRoll() != nil || result.Damage() != 0 {
+				t.Errorf("Expected a miss, got %s", result)
+			}
+		}
+	}
+}
+
+// TestAttackCriticalRange tests attacks with an expanded critical range
+func TestAttackCriticalRange(t *testing.T) {
+	a := NewAttack(ParseDice("1d20+5"), NewConstant(6), WithAttackCriticalDamage(CriticalTimesTwo),
+		WithAttackRollOptions(WithCriticalHit(19)))
+
+	for i := 0; i < 200; i++ {
+		result := a.Resolve(NewDifficultyClass(30))
+		natural := result.ToHitRoll().GetAllRolls()[0].Value()
+		if natural >= 19 {
+			if !result.IsCriticalHit() || result.Damage() != 12 {
+				t.Errorf("Expected a natural %d to be a critical hit for 12 damage, got %s", natural, result)
+			}
+		} else if result.Hit() {
+			t.Errorf("Expected a natural %d to miss AC 30, got %s", natural, result)
+		}
+	}
+
+	// Every roll is a critical hit
+	result := a.Resolve(NewDifficultyClass(30), WithCriticalHit(1))
+	if !result.Hit() || !result.IsCriticalHit() {
+		t.Errorf("Expected a critical hit, got %s", result)
+	}
+}
+
+// TestAttackAdvantage tests attacks rolled with advantage and disadvantage
+func TestAttackAdvantage(t *testing.T) {
+	a := NewAttack(ParseDice("1d20+3"), ParseDice("1d6"))
+
+	result := a.Resolve(NewDifficultyClass(10), WithAdvantage())
+	if !result.ToHitRoll().RolledWithAdvantage() {
+		t.Errorf("Expected the attack to be rolled with advantage, got %s", result)
+	}
+	result = a.Resolve(NewDifficultyClass(10), WithDisadvantage())
+	if !result.ToHitRoll().RolledWithDisadvantage() {
+		t.Errorf("Expected the attack to be rolled with disadvantage, got %s", result)
+	}
+	result = a.Resolve(NewDifficultyClass(10), WithAdvantage(), WithDisadvantage())
+	if result.ToHitRoll().RolledWithAdvantage() || result.ToHitRoll().RolledWithDisadvantage() {
+		t.Errorf("Expected advantage and disadvantage to cancel, got %s", result)
+	}
+}
+
+// TestAttackPresetToHit tests attacks whose to-hit dice are preset dice
+func TestAttackPresetToHit(t *testing.T) {
+	a := NewAttack(NewPresetDice(ParseDice("1d20+5"), WithAdvantage()), ParseDice("1d6"))
+	if a.ToHit().String() != "1d20 + 5" {
+		t.Errorf("Expected to-hit dice `1d20 + 5`, got `%s`", a.ToHit())
+	}
+
+	criticals := 0
+	for range 2000 {
+		result := a.Resolve(NewDifficultyClass(15))
+		if !result.ToHitRoll().RolledWithAdvantage() {
+			t.Fatalf("Expected the attack to be rolled with advantage, got %s", result)
+		}
+		if result.IsCriticalHit() {
+			criticals++
+		}
+	}
+
+	// With advantage, a critical hit is rolled just under 10% of the time
+	if criticals < 120 || criticals > 280 {
+		t.Errorf("Expected about 195 critical hits, got %d", criticals)
+	}
+}
+
+// TestAttackEmptyToHit tests that an attack with no dice to hit rolls a flat 0
+func TestAttackEmptyToHit(t *testing.T) {
+	a := NewAttack(NewDiceSet(), ParseDice("1d8"))
+	if result := a.Resolve(NewDifficultyClass(0)); !result.Hit() || result.IsCriticalHit() {
+		t.Errorf("Expected a 0 to hit AC 0, got %s", result)
+	}
+	if result := a.Resolve(NewDifficultyClass(5)); result.Hit() {
+		t.Errorf("Expected a 0 to miss AC 5, got %s", result)
+	}
+}
+
+// TestMultiattack tests resolving a sequence of attacks
+func TestMultiattack(t *testing.T) {
+	m := NewMultiattack(
+		NewAttack(NewConstant(20), NewConstant(7), WithAttackName("Bite")),
+		NewAttack(NewConstant(12), NewConstant(5), WithAttackName("Claw")),
+		NewAttack(NewConstant(16), NewConstant(5), WithAttackName("Claw")),
+	)
+	if len(m.Attacks()) != 3 {
+		t.Fatalf("Expected 3 attacks, got %d", len(m.Attacks()))
+	}
+
+	result := m.Resolve(NewDifficultyClass(15))
+	if len(result.Results()) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(result.Results()))
+	}
+	if result.Hits() != 2 {
+		t.Errorf("Expected 2 hits, got %d", result.Hits())
+	}
+	if result.Damage() != 12 {
+		t.Errorf("Expected 12 damage, got %d", result.Damage())
+	}
+
+	lines := strings.Split(result.String(), "\n")
+	expected := []string{
+		"Bite: 20 = 20 vs AC 15: Hit for 7 = 7 damage",
+		"Claw: 12 = 12 vs AC 15: Miss",
+		"Claw: 16 = 16 vs AC 15: Hit for 5 = 5 damage",
+		"Total: 12 damage",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, got `%s`", len(expected), result)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("Expected `%s`, got `%s`", expected[i], lines[i])
+		}
+	}
+}
+
+// TestAttackString tests the string representation of an attack
+func TestAttackString(t *testing.T) {
+	a := NewAttack(ParseDice("1d20+5"), ParseDice("1d8+3", WithDamageType(Slashing)), WithAttackName("Longsword"))
+	expected := "Longsword: 1d20 + 5 to hit, 1d8+3 slashing damage"
+	if a.String() != expected {
+		t.Errorf("Expected `%s`, got `%s`", expected, a)
+	}
+}
@@ -0,0 +1,279 @@
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxBagDraws is the most tokens drawn for a single draw, to limit how long tokens that say to draw
+// again keep drawing.
+const MaxBagDraws = 20
+
+var (
+	ErrBagEmpty      = errors.New("bag is empty")
+	ErrTokenNotFound = errors.New("token not found")
+)
+
+// Token is a token in a bag, such as a chaos bag in Arkham Horror, that modifies a skill test when
+// it is drawn.
+type Token struct {
+	Label     string // The label of the token, such as `Skull`; may be empty for plain numeric tokens
+	Value     int    // The modifier added to the skill test
+	DrawAgain bool   // If true, another token is drawn and added to the test
+	AutoFail  bool   // If true, the skill test fails no matter the value
+}
+
+// BagDraw is the result of drawing from a bag. The value is the skill the draw was made for plus the
+// modifiers of every token drawn, so the draw can be checked against a DifficultyClass. A draw that
+// includes an auto-fail token is a critical miss.
+type BagDraw interface {
+	Value            // The skill plus the modifiers of the tokens
+	Skill() int      // The skill the draw was made for
+	Tokens() []Token // The tokens drawn, in the order they were drawn
+	Modifier() int   // The sum of the modifiers of the tokens
+	fmt.Stringer     // String representation of the draw
+}
+
+// Bag is a bag of tokens that are drawn at random.
+type Bag interface {
+	Tokens() []Token                                          // The tokens in the bag
+	Add(tokens ...Token)                                      // Adds tokens to the bag
+	Remove(label string) error                                // Removes a token with the label from the bag
+	Draw(skill int) (BagDraw, error)                          // Draws tokens for a skill test
+	Return(tokens ...Token)                                   // Puts tokens drawn without replacement back in the bag
+	SuccessProbability(skill int, dc DifficultyClass) float64 // The exact chance that a draw succeeds
+	fmt.Stringer                                              // String representation of the bag
+}
+
+// bag is an implementation of the Bag interface.
+type bag struct {
+	tokens             []Token // The tokens in the bag
+	withoutReplacement bool    // If true, drawn tokens are not put back in the bag
+}
+
+// bagDraw is an implementation of the BagDraw interface.
+type bagDraw struct {
+	skill  int     // The skill the draw was made for
+	tokens []Token // The tokens drawn
+}
+
+// BagOption is a function that can modify the default values of a bag.
+type BagOption func(*bag)
+
+// NewBag creates a bag with the tokens. By default, tokens are put back in the bag after each draw.
+func NewBag(tokens []Token, opts ...BagOption) Bag {
+	b := &bag{
+		tokens: make([]Token, 0, len(tokens)),
+	}
+	b.tokens = append(b.tokens, tokens...)
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// WithoutReplacement keeps drawn tokens out of the bag until they are put back with Return.
+func WithoutReplacement() BagOption {
+	return func(b *bag) {
+		b.withoutReplacement = true
+	}
+}
+
+// Tokens returns a copy of the tokens in the bag.
+func (b *bag) Tokens() []Token {
+	tokens := make([]Token, len(b.tokens))
+	copy(tokens, b.tokens)
+	return tokens
+}
+
+// Add adds tokens to the bag.
+func (b *bag) Add(tokens ...Token) {
+	b.tokens = append(b.tokens, tokens...)
+}
+
+// Remove removes a token with the label from the bag. An error is returned if there is no such token.
+func (b *bag) Remove(label string) error {
+	for i, token := range b.tokens {
+		if token.Label == label {
+			b.tokens = append(b.tokens[:i], b.tokens[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrTokenNotFound, label)
+}
+
+// Draw draws a token for a skill test, adding its modifier to the skill. If the token says to draw
+// again, more tokens are drawn until one does not, the bag is empty, or MaxBagDraws tokens have been
+// drawn. Drawing stops at an auto-fail token. Tokens are not put back while drawing again; afterwards,
+// they are put back unless the bag was created WithoutReplacement. Use a skill of 0 to draw for the
+// modifiers alone. An error is returned if the bag is empty.
+func (b *bag) Draw(skill int) (BagDraw, error) {
+	if len(b.tokens) == 0 {
+		return nil, ErrBagEmpty
+	}
+
+	bd := &bagDraw{
+		skill: skill,
+	}
+	remaining := b.tokens
+	if !b.withoutReplacement {
+		remaining = b.Tokens()
+	}
+	for len(remaining) > 0 && len(bd.tokens) < MaxBagDraws {
+		i := rng.Intn(len(remaining))
+		token := remaining[i]
+		remaining = append(remaining[:i], remaining[i+1:]...)
+		bd.tokens = append(bd.tokens, token)
+		if token.AutoFail || !token.DrawAgain {
+			break
+		}
+	}
+	if b.withoutReplacement {
+		b.tokens = remaining
+	}
+
+	return bd, nil
+}
+
+// Return puts tokens drawn without replacement back in the bag.
+func (b *bag) Return(tokens ...Token) {
+	b.Add(tokens...)
+}
+
+// SuccessProbability returns the exact chance that a draw for a skill test with the skill succeeds
+// against the difficulty class, given the tokens currently in the bag.
+func (b *bag) SuccessProbability(skill int, dc DifficultyClass) float64 {
+	if len(b.tokens) == 0 {
+		return 0
+	}
+
+	counts := make(map[Token]int)
+	for _, token := range b.tokens {
+		counts[token]++
+	}
+	return successProbability(counts, len(b.tokens), skill, 0, dc)
+}
+
+// successProbability returns the chance of success when drawing from the remaining tokens, after
+// drawn tokens have added the modifier to the skill.
+func successProbability(counts map[Token]int, remaining int, skill int, drawn int, dc DifficultyClass) float64 {
+	probability := 0.0
+	for token, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / float64(remaining)
+		switch {
+		case token.AutoFail:
+			// The test fails
+		case token.DrawAgain && remaining > 1 && drawn+1 < MaxBagDraws:
+			counts[token]--
+			probability += p * successProbability(counts, remaining-1, skill+token.Value, drawn+1, dc)
+			counts[token]++
+		case skill+token.Value >= dc.Value():
+			probability += p
+		}
+	}
+	return probability
+}
+
+// String returns a string representation of the bag, such as `Bag: +0, +1, -1, Skull -2 [draw again]`.
+func (b *bag) String() string {
+	tokens := make([]string, 0, len(b.tokens))
+	for _, token := range b.tokens {
+		tokens = append(tokens, token.String())
+	}
+	sort.Strings(tokens)
+	return "Bag: " + strings.Join(tokens, ", ")
+}
+
+// String returns a string representation of the token, such as `Skull -2 [draw again]`.
+func (t Token) String() string {
+	parts := make([]string, 0, 4)
+	if t.Label != "" {
+		parts = append(parts, t.Label)
+	}
+	if t.Value != 0 || t.Label == "" {
+		parts = append(parts, formatModifier(t.Value))
+	}
+	if t.DrawAgain {
+		parts = append(parts, "[draw again]")
+	}
+	if t.AutoFail {
+		parts = append(parts, "[auto-fail]")
+	}
+	return strings.Join(parts, " ")
+}
+
+// Skill returns the skill the draw was made for.
+func (bd *bagDraw) Skill() int {
+	return bd.skill
+}
+
+// Tokens returns the tokens drawn, in the order they were drawn.
+func (bd *bagDraw) Tokens() []Token {
+	return bd.tokens
+}
+
+// Modifier returns the sum of the modifiers of the tokens drawn.
+func (bd *bagDraw) Modifier() int {
+	modifier := 0
+	for _, token := range bd.tokens {
+		modifier += token.Value
+	}
+	return modifier
+}
+
+// Value returns the skill plus the modifiers of the tokens drawn.
+func (bd *bagDraw) Value() int {
+	return bd.skill + bd.Modifier()
+}
+
+// Check returns true if the draw did not include an auto-fail token and its value is equal to or
+// greater than the value.
+func (bd *bagDraw) Check(v Value) bool {
+	if bd.IsCriticalMiss() {
+		return false
+	}
+	return bd.Value() >= v.Value()
+}
+
+// IsCriticalHit returns false, as tokens do not have critical hits.
+func (bd *bagDraw) IsCriticalHit() bool {
+	return false
+}
+
+// IsCriticalMiss returns true if the draw included an auto-fail token.
+func (bd *bagDraw) IsCriticalMiss() bool {
+	for _, token := range bd.tokens {
+		if token.AutoFail {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a string representation of the draw, such as `4 -1 (Skull) +1 = 4`, with
+// `(Fail!)` added if an auto-fail token was drawn.
+func (bd *bagDraw) String() string {
+	var sb strings.Builder
+	sb.WriteString(strconv.Itoa(bd.skill))
+	for _, token := range bd.tokens {
+		sb.WriteString(" ")
+		sb.WriteString(formatModifier(token.Value))
+		if token.Label != "" {
+			sb.WriteString(" (")
+			sb.WriteString(token.Label)
+			sb.WriteString(")")
+		}
+	}
+	sb.WriteString(" = ")
+	sb.WriteString(strconv.Itoa(bd.Value()))
+	if bd.IsCriticalMiss() {
+		sb.WriteString(" (Fail!)")
+	}
+	return sb.String()
+}
@@ -0,0 +1,144 @@
+package dice
+
+import (
+	"errors"
+	"testing"
+)
+
+// chaosBag returns a bag with a small set of tokens for testing
+func chaosBag(opts ...BagOption) Bag {
+	return NewBag([]Token{
+		{Value: 1},
+		{Value: 0},
+		{Value: -1},
+		{Value: -2},
+		{Label: "Skull", Value: -1, DrawAgain: true},
+		{Label: "Tentacle", AutoFail: true},
+	}, opts...)
+}
+
+// TestBagDraw tests drawing tokens for a skill test
+func TestBagDraw(t *testing.T) {
+	b := chaosBag()
+	dc := NewDifficultyClass(3)
+	for range 1000 {
+		draw, err := b.Draw(4)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		tokens := draw.Tokens()
+		if len(tokens) == 0 || len(tokens) > 6 {
+			t.Fatalf("Unexpected tokens %v", tokens)
+		}
+		for i, token := range tokens[:len(tokens)-1] {
+			if !token.DrawAgain {
+				t.Errorf("Token %d was followed by another token: %s", i, draw)
+			}
+		}
+		if draw.Value() != 4+draw.Modifier() {
+			t.Errorf("Expected the skill plus the modifier, got %s", draw)
+		}
+		if tokens[len(tokens)-1].AutoFail != draw.IsCriticalMiss() {
+			t.Errorf("Unexpected critical miss for %s", draw)
+		}
+		if dc.Check(draw) != (!draw.IsCriticalMiss() && draw.Value() >= 3) {
+			t.Errorf("Unexpected check of %s against %s", draw, dc)
+		}
+	}
+	if len(b.Tokens()) != 6 {
+		t.Errorf("Expected the tokens to be put back, got %s", b)
+	}
+}
+
+// TestBagWithoutReplacement tests drawing tokens without putting them back
+func TestBagWithoutReplacement(t *testing.T) {
+	b := NewBag([]Token{{Value: 1}, {Value: 2}, {Label: "Bless", Value: 2, DrawAgain: true}}, WithoutReplacement())
+
+	var drawn []Token
+	for len(b.Tokens()) > 0 {
+		draw, err := b.Draw(0)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		drawn = append(drawn, draw.Tokens()...)
+	}
+	if len(drawn) != 3 {
+		t.Errorf("Expected to draw every token once, got %v", drawn)
+	}
+	if _, err := b.Draw(0); !errors.Is(err, ErrBagEmpty) {
+		t.Errorf("Expected %v, got %v", ErrBagEmpty, err)
+	}
+
+	b.Return(drawn...)
+	if len(b.Tokens()) != 3 {
+		t.Errorf("Expected the tokens to be returned, got %s", b)
+	}
+	if err := b.Remove("Bless"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := b.Remove("Bless"); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("Expected %v, got %v", ErrTokenNotFound, err)
+	}
+}
+
+// TestBagSuccessProbability tests the exact chance of success
+func TestBagSuccessProbability(t *testing.T) {
+	b := NewBag([]Token{{Value: 0}, {Value: -1}, {Label: "Tentacle", AutoFail: true}, {Label: "Skull", Value: -1, DrawAgain: true}})
+
+	tests := []struct {
+		skill    int
+		dc       int
+		expected float64
+	}{
+		// Only 0 succeeds; after the skull, the skill is too low
+		{3, 3, 1.0 / 4},
+		// 0 and -1 succeed; after the skull, only the tentacle fails
+		{5, 3, 1.0/4 + 1.0/4 + 1.0/4*2.0/3},
+		{1, 5, 0},
+		{9, 0, 1.0/4 + 1.0/4 + 1.0/4*2.0/3},
+	}
+	for _, test := range tests {
+		p := b.SuccessProbability(test.skill, NewDifficultyClass(test.dc))
+		if !almostEqual(p, test.expected) {
+			t.Errorf("Skill %d against %d: expected %f, got %f", test.skill, test.dc, test.expected, p)
+		}
+	}
+
+	// The exact chance matches drawing from the bag
+	b = chaosBag()
+	dc := NewDifficultyClass(4)
+	expected := b.SuccessProbability(4, dc)
+	successes := 0
+	const draws = 100000
+	for range draws {
+		draw, _ := b.Draw(4)
+		if dc.Check(draw) {
+			successes++
+		}
+	}
+	if got := float64(successes) / draws; got < expected-0.01 || got > expected+0.01 {
+		t.Errorf("Expected about %f, got %f", expected, got)
+	}
+
+	if p := NewBag(nil).SuccessProbability(10, dc); p != 0 {
+		t.Errorf("Expected 0 for an empty bag, got %f", p)
+	}
+}
+
+// TestBagString tests the string representation of bags, tokens and draws
+func TestBagString(t *testing.T) {
+	b := NewBag([]Token{{Value: 0}, {Label: "Skull", Value: -2, DrawAgain: true}, {Label: "Tentacle", AutoFail: true}})
+	expected := "Bag: +0, Skull -2 [draw again], Tentacle [auto-fail]"
+	if b.String() != expected {
+		t.Errorf("Expected `%s`, got `%s`", expected, b)
+	}
+
+	draw := &bagDraw{skill: 4, tokens: []Token{{Label: "Skull", Value: -2, DrawAgain: true}, {Value: 1}}}
+	if draw.String() != "4 -2 (Skull) +1 = 3" {
+		t.Errorf("Unexpected string `%s`", draw)
+	}
+	draw = &bagDraw{skill: 4, tokens: []Token{{Label: "Tentacle", AutoFail: true}}}
+	if draw.String() != "4 +0 (Tentacle) = 4 (Fail!)" {
+		t.Errorf("Unexpected string `%s`", draw)
+	}
+}
@@ -0,0 +1,200 @@
+package dice
+
+// Condition is a condition that affects a creature, such as being poisoned or prone.
+type Condition string
+
+// Pre-defined conditions
+const (
+	Blinded       Condition = "blinded"
+	Charmed       Condition = "charmed"
+	Deafened      Condition = "deafened"
+	Frightened    Condition = "frightened"
+	Grappled      Condition = "grappled"
+	Incapacitated Condition = "incapacitated"
+	Invisible     Condition = "invisible"
+	Paralyzed     Condition = "paralyzed"
+	Petrified     Condition = "petrified"
+	Poisoned      Condition = "poisoned"
+	Prone         Condition = "prone"
+	Restrained    Condition = "restrained"
+	Stunned       Condition = "stunned"
+	Unconscious   Condition = "unconscious"
+)
+
+// RollKind is the kind of roll a condition affects.
+type RollKind int
+
+const (
+	_                 RollKind = iota
+	RollAttack                 // An attack roll made by the creature
+	RollAttackAgainst          // An attack roll made against the creature
+	RollAbilityCheck           // An ability check made by the creature
+	RollSavingThrow            // A saving throw made by the creature
+)
+
+// RollContext is the situation in which a roll is made.
+type RollContext struct {
+	Ability        Ability // The ability used for an ability check or saving throw
+	WithinFiveFeet bool    // If true, the attacker is within 5 feet of the target
+}
+
+// ConditionRule maps a condition and a kind of roll to the options used for the roll.
+type ConditionRule struct {
+	Condition Condition                  // The condition the rule applies to
+	Kind      RollKind                   // The kind of roll the rule applies to
+	When      func(ctx RollContext) bool // If set, the rule only applies when this returns true
+	Options   []RollOption               // The options used for the roll
+}
+
+// ConditionTable is a table of rules that translate conditions into the options used for a roll.
+type ConditionTable interface {
+	Rules() []ConditionRule                                                               // The rules in the table
+	Options(kind RollKind, ctx RollContext, conditions ...Condition) []RollOption         // The merged options for the conditions
+	AttackOptions(attacker []Condition, target []Condition, ctx RollContext) []RollOption // The merged options for an attack
+}
+
+// conditionTable is an implementation of the ConditionTable interface.
+type conditionTable []ConditionRule
+
+// DefaultConditionRules are the effects of conditions on attack rolls, ability checks and saving throws.
+// Effects that cannot be expressed as roll options, such as automatically failing a saving throw, are
+// not included.
+var DefaultConditionRules = []ConditionRule{
+	{Condition: Blinded, Kind: RollAttack, Options: []RollOption{WithDisadvantage()}},
+	{Condition: Blinded, Kind: RollAttackAgainst, Options: []RollOption{WithAdvantage()}},
+	{Condition: Frightened, Kind: RollAttack, Options: []RollOption{WithDisadvantage()}},
+	{Condition: Frightened, Kind: RollAbilityCheck, Options: []RollOption{WithDisadvantage()}},
+	{Condition: Invisible, Kind: RollAttack, Options: []RollOption{WithAdvantage()}},
+	{Condition: Invisible, Kind: RollAttackAgainst, Options: []RollOption{WithDisadvantage()}},
+	{Condition: Paralyzed, Kind: RollAttackAgainst, Options: []RollOption{WithAdvantage()}},
+	{Condition: Petrified, Kind: RollAttackAgainst, Options: []RollOption{WithAdvantage()}},
+	{Condition: Poisoned, Kind: RollAttack, Options: []RollOption{WithDisadvantage()}},
+	{Condition: Poisoned, Kind: RollAbilityCheck, Options: []RollOption{WithDisadvantage()}},
+	{Condition: Prone, Kind: RollAttack, Options: []RollOption{WithDisadvantage()}},
+	{Condition: Prone, Kind: RollAttackAgainst, When: withinFiveFeet, Options: []RollOption{WithAdvantage()}},
+	{Condition: Prone, Kind: RollAttackAgainst, When: beyondFiveFeet, Options: []RollOption{WithDisadvantage()}},
+	{Condition: Restrained, Kind: RollAttack, Options: []RollOption{WithDisadvantage()}},
+	{Condition: Restrained, Kind: RollAttackAgainst, Options: []RollOption{WithAdvantage()}},
+	{Condition: Restrained, Kind: RollSavingThrow, When: usesAbility(Dexterity), Options: []RollOption{WithDisadvantage()}},
+	{Condition: Stunned, Kind: RollAttackAgainst, Options: []RollOption{WithAdvantage()}},
+	{Condition: Unconscious, Kind: RollAttackAgainst, Options: []RollOption{WithAdvantage()}},
+}
+
+// NewConditionTable creates a table with the rules. If no rules are provided, the
+// DefaultConditionRules are used.
+func NewConditionTable(rules ...ConditionRule) ConditionTable {
+	if len(rules) == 0 {
+		rules = DefaultConditionRules
+	}
+	table := make(conditionTable, 0, len(rules))
+	table = append(table, rules...)
+	return table
+}
+
+// ResolveRollOptions merges the options so that advantage and disadvantage cancel out. If there is at
+// least one source of advantage and at least one source of disadvantage, the roll is made normally, no
+// matter how many of each there are. Options other than advantage and disadvantage are kept in order.
+func ResolveRollOptions(opts ...RollOption) []RollOption {
+	var advantage, disadvantage bool
+	resolved := make([]RollOption, 0, len(opts)+1)
+	for _, opt := range opts {
+		r := &roll{
+			rollType: RollOnce,
+		}
+		opt(r)
+		switch r.rollType {
+		case RollWithAdvantage:
+			advantage = true
+		case RollWithDisadvantage:
+			disadvantage = true
+		default:
+			resolved = append(resolved, opt)
+		}
+	}
+
+	switch {
+	case advantage && !disadvantage:
+		resolved = append(resolved, WithAdvantage())
+	case disadvantage && !advantage:
+		resolved = append(resolved, WithDisadvantage())
+	}
+
+	return resolved
+}
+
+// withinFiveFeet returns true if the attacker is within 5 feet of the target.
+func withinFiveFeet(ctx RollContext) bool {
+	return ctx.WithinFiveFeet
+}
+
+// beyondFiveFeet returns true if the attacker is more than 5 feet from the target.
+func beyondFiveFeet(ctx RollContext) bool {
+	return !ctx.WithinFiveFeet
+}
+
+// usesAbility returns a function that is true if the roll uses the ability.
+func usesAbility(ability Ability) func(ctx RollContext) bool {
+	return func(ctx RollContext) bool {
+		return ctx.Ability == ability
+	}
+}
+
+// Rules returns the rules in the table.
+func (ct conditionTable) Rules() []ConditionRule {
+	return ct
+}
+
+// Options returns the merged options for a roll of the kind made by, or against, a creature with the
+// conditions.
+func (ct conditionTable) Options(kind RollKind, ctx RollContext, conditions ...Condition) []RollOption {
+	return ResolveRollOptions(ct.options(kind, ctx, conditions)...)
+}
+
+// AttackOptions returns the merged options for an attack made by a creature with the attacker
+// conditions against a creature with the target conditions.
+func (ct conditionTable) AttackOptions(attacker []Condition, target []Condition, ctx RollContext) []RollOption {
+	opts := ct.options(RollAttack, ctx, attacker)
+	opts = append(opts, ct.options(RollAttackAgainst, ctx, target)...)
+	return ResolveRollOptions(opts...)
+}
+
+// options returns the options for all rules that apply to the roll, without merging them.
+func (ct conditionTable) options(kind RollKind, ctx RollContext, conditions []Condition) []RollOption {
+	var opts []RollOption
+	for _, rule := range ct {
+		if rule.Kind != kind || !containsCondition(conditions, rule.Condition) {
+			continue
+		}
+		if rule.When != nil && !rule.When(ctx) {
+			continue
+		}
+		opts = append(opts, rule.Options...)
+	}
+	return opts
+}
+
+// containsCondition returns true if the condition is included in the conditions.
+func containsCondition(conditions []Condition, condition Condition) bool {
+	for _, c := range conditions {
+		if c == condition {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a string representation of the roll kind.
+func (k RollKind) String() string {
+	switch k {
+	case RollAttack:
+		return "Attack"
+	case RollAttackAgainst:
+		return "Attack Against"
+	case RollAbilityCheck:
+		return "Ability Check"
+	case RollSavingThrow:
+		return "Saving Throw"
+	default:
+		return "Unknown"
+	}
+}
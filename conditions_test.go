@@ -0,0 +1,123 @@
+package dice
+
+import "testing"
+
+// rollTypeOf returns the roll type produced by applying the options to a roll
+func rollTypeOf(opts []RollOption) RollType {
+	r := &roll{
+		rollType: RollOnce,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r.rollType
+}
+
+// TestResolveRollOptions tests merging advantage and disadvantage
+func TestResolveRollOptions(t *testing.T) {
+	tests := []struct {
+		opts     []RollOption
+		expected RollType
+	}{
+		{nil, RollOnce},
+		{[]RollOption{WithAdvantage()}, RollWithAdvantage},
+		{[]RollOption{WithAdvantage(), WithAdvantage()}, RollWithAdvantage},
+		{[]RollOption{WithDisadvantage(), WithDisadvantage()}, RollWithDisadvantage},
+		{[]RollOption{WithAdvantage(), WithDisadvantage()}, RollOnce},
+		{[]RollOption{WithAdvantage(), WithDisadvantage(), WithAdvantage()}, RollOnce},
+		{[]RollOption{WithDisadvantage(), WithAdvantage(), WithAdvantage(), WithDisadvantage()}, RollOnce},
+	}
+
+	for i, test := range tests {
+		if rollType := rollTypeOf(ResolveRollOptions(test.opts...)); rollType != test.expected {
+			t.Errorf("Test %d: expected roll type %d, got %d", i, test.expected, rollType)
+		}
+	}
+
+	// Other options are kept
+	opts := ResolveRollOptions(WithCriticalHit(19), WithAdvantage())
+	r := &roll{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.criticalHit != 19 || r.rollType != RollWithAdvantage {
+		t.Errorf("Expected critical hit 19 with advantage, got %d and %d", r.criticalHit, r.rollType)
+	}
+}
+
+// TestConditionOptions tests the options for conditions on a creature
+func TestConditionOptions(t *testing.T) {
+	table := NewConditionTable()
+
+	tests := []struct {
+		kind       RollKind
+		ctx        RollContext
+		conditions []Condition
+		expected   RollType
+	}{
+		{RollAttack, RollContext{}, []Condition{Poisoned}, RollWithDisadvantage},
+		{RollAbilityCheck, RollContext{}, []Condition{Poisoned}, RollWithDisadvantage},
+		{RollSavingThrow, RollContext{}, []Condition{Poisoned}, RollOnce},
+		{RollAttack, RollContext{}, []Condition{Poisoned, Invisible}, RollOnce},
+		{RollAttack, RollContext{}, []Condition{Poisoned, Blinded, Invisible}, RollOnce},
+		{RollAttackAgainst, RollContext{WithinFiveFeet: true}, []Condition{Prone}, RollWithAdvantage},
+		{RollAttackAgainst, RollContext{}, []Condition{Prone}, RollWithDisadvantage},
+		{RollSavingThrow, RollContext{Ability: Dexterity}, []Condition{Restrained}, RollWithDisadvantage},
+		{RollSavingThrow, RollContext{Ability: Strength}, []Condition{Restrained}, RollOnce},
+		{RollAttack, RollContext{}, []Condition{Charmed}, RollOnce},
+	}
+
+	for _, test := range tests {
+		opts := table.Options(test.kind, test.ctx, test.conditions...)
+		if rollType := rollTypeOf(opts); rollType != test.expected {
+			t.Errorf("Expected roll type %d for %s with %v, got %d", test.expected, test.kind, test.conditions, rollType)
+		}
+	}
+}
+
+// TestConditionAttackOptions tests the options for attacks between creatures with conditions
+func TestConditionAttackOptions(t *testing.T) {
+	table := NewConditionTable()
+
+	// A prone target at range and an invisible attacker cancel out
+	opts := table.AttackOptions([]Condition{Invisible}, []Condition{Prone}, RollContext{})
+	if rollType := rollTypeOf(opts); rollType != RollOnce {
+		t.Errorf("Expected a normal roll, got %d", rollType)
+	}
+
+	// A poisoned attacker against an unconscious target cancel out
+	opts = table.AttackOptions([]Condition{Poisoned}, []Condition{Unconscious}, RollContext{WithinFiveFeet: true})
+	if rollType := rollTypeOf(opts); rollType != RollOnce {
+		t.Errorf("Expected a normal roll, got %d", rollType)
+	}
+
+	opts = table.AttackOptions(nil, []Condition{Restrained, Prone}, RollContext{WithinFiveFeet: true})
+	if rollType := rollTypeOf(opts); rollType != RollWithAdvantage {
+		t.Errorf("Expected advantage, got %d", rollType)
+	}
+}
+
+// TestCustomConditionTable tests a table with custom rules
+func TestCustomConditionTable(t *testing.T) {
+	table := NewConditionTable(
+		ConditionRule{Condition: "blessed", Kind: RollSavingThrow, Options: []RollOption{WithCriticalHitAllowed()}},
+		ConditionRule{Condition: "hasted", Kind: RollSavingThrow, When: usesAbility(Dexterity), Options: []RollOption{WithAdvantage()}},
+	)
+	if len(table.Rules()) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(table.Rules()))
+	}
+
+	opts := table.Options(RollSavingThrow, RollContext{Ability: Dexterity}, "blessed", "hasted")
+	r := &roll{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if !r.criticalHitAllowed || r.rollType != RollWithAdvantage {
+		t.Errorf("Expected critical hits allowed with advantage, got %v and %d", r.criticalHitAllowed, r.rollType)
+	}
+
+	roll := D20.Roll(table.Options(RollSavingThrow, RollContext{Ability: Wisdom}, "hasted")...)
+	if roll.RolledWithAdvantage() {
+		t.Errorf("Expected a normal roll, got %s", roll)
+	}
+}
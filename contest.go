@@ -0,0 +1,244 @@
+package dice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TieRule determines the winner of a contest when both sides roll the same value.
+type TieRule int
+
+const (
+	_                     TieRule = iota
+	TieDefenderWins               // Side B, the defender, wins all ties
+	TieReroll                     // Both sides re-roll until the tie is broken
+	TieHigherModifierWins         // The side with the higher modifier wins; the defender wins if they are equal
+)
+
+// ContestSide identifies a side in a contest.
+type ContestSide int
+
+const (
+	_            ContestSide = iota
+	ContestSideA             // The first side in the contest
+	ContestSideB             // The second side in the contest, which is the defender
+)
+
+const (
+	MaxContestRerolls = 100 // The maximum number of times a tie is re-rolled before the defender wins
+)
+
+// ContestResult is the result of a contest between two dice.
+type ContestResult interface {
+	RollA() Roll               // The final roll for side A
+	RollB() Roll               // The final roll for side B
+	Ties() [][2]Roll           // The tied rolls that were re-rolled, in the order they were rolled
+	Winner() ContestSide       // The side that won the contest
+	AWins() bool               // Returns true if side A won the contest
+	IsTie() bool               // Returns true if both sides rolled the same value in the final roll
+	Margin() int               // The amount by which the winning roll exceeded the losing roll
+	ProbabilityAWins() float64 // The exact probability that side A wins the contest
+	fmt.Stringer               // String representation of the contest
+}
+
+// contest is a contest between two dice.
+type contest struct {
+	a       Dice         // The dice for side A
+	b       Dice         // The dice for side B
+	aOpts   []RollOption // The options used when rolling for side A
+	bOpts   []RollOption // The options used when rolling for side B
+	tieRule TieRule      // The rule used to resolve ties
+}
+
+// contestResult is an implementation of the ContestResult interface.
+type contestResult struct {
+	contest *contest    // The contest that was resolved
+	rollA   Roll        // The final roll for side A
+	rollB   Roll        // The final roll for side B
+	ties    [][2]Roll   // Any tied rolls that were re-rolled
+	winner  ContestSide // The side that won the contest
+}
+
+// ContestOption is a function that can modify the default values of a contest.
+type ContestOption func(*contest)
+
+// Contest rolls the two dice against each other, with side B being the defender. The side with the
+// higher roll wins, and ties are resolved using the tie rule, which defaults to TieDefenderWins.
+func Contest(a, b Dice, opts ...ContestOption) ContestResult {
+	c := newContest(a, b, opts...)
+
+	result := &contestResult{
+		contest: c,
+	}
+	for {
+		result.rollA = a.Roll(c.aOpts...)
+		result.rollB = b.Roll(c.bOpts...)
+		if result.rollA.Value() != result.rollB.Value() ||
+			c.tieRule != TieReroll || len(result.ties) >= MaxContestRerolls {
+			break
+		}
+		result.ties = append(result.ties, [2]Roll{result.rollA, result.rollB})
+	}
+	result.winner = c.winner(result.rollA.Value(), result.rollB.Value())
+
+	return result
+}
+
+// ContestProbability returns the exact probability that side A wins a contest between the two dice.
+func ContestProbability(a, b Dice, opts ...ContestOption) float64 {
+	return newContest(a, b, opts...).probabilityAWins()
+}
+
+// WithTieRule sets the rule used to resolve ties.
+func WithTieRule(rule TieRule) ContestOption {
+	return func(c *contest) {
+		c.tieRule = rule
+	}
+}
+
+// WithRollOptionsA sets the options used when rolling the dice for side A.
+func WithRollOptionsA(opts ...RollOption) ContestOption {
+	return func(c *contest) {
+		c.aOpts = append(c.aOpts, opts...)
+	}
+}
+
+// WithRollOptionsB sets the options used when rolling the dice for side B.
+func WithRollOptionsB(opts ...RollOption) ContestOption {
+	return func(c *contest) {
+		c.bOpts = append(c.bOpts, opts...)
+	}
+}
+
+// newContest creates a contest between the two dice with the options applied.
+func newContest(a, b Dice, opts ...ContestOption) *contest {
+	c := &contest{
+		a:       a,
+		b:       b,
+		tieRule: TieDefenderWins,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// winner returns the side that wins the contest given the values rolled by each side.
+func (c *contest) winner(a int, b int) ContestSide {
+	switch {
+	case a > b:
+		return ContestSideA
+	case a < b:
+		return ContestSideB
+	case c.tieRule == TieHigherModifierWins && totalModifier(c.a) > totalModifier(c.b):
+		return ContestSideA
+	default:
+		return ContestSideB
+	}
+}
+
+// probabilityAWins returns the exact probability that side A wins the contest.
+func (c *contest) probabilityAWins() float64 {
+	greater, equal, _ := diceDistribution(c.a, c.aOpts...).compare(diceDistribution(c.b, c.bOpts...))
+
+	switch {
+	case c.tieRule == TieReroll && equal < 1:
+		// Ties are re-rolled, so only the rolls that are not a tie count
+		return greater / (1 - equal)
+	case c.tieRule == TieHigherModifierWins && totalModifier(c.a) > totalModifier(c.b):
+		return greater + equal
+	default:
+		return greater
+	}
+}
+
+// totalModifier returns the sum of the constant modifiers for all dice.
+func totalModifier(d Dice) int {
+	var modifier int
+	for _, die := range d.GetDice() {
+		if die.IsDebuff() {
+			modifier -= die.Modifier()
+		} else {
+			modifier += die.Modifier()
+		}
+	}
+	return modifier
+}
+
+// RollA returns the final roll for side A.
+func (cr *contestResult) RollA() Roll {
+	return cr.rollA
+}
+
+// RollB returns the final roll for side B.
+func (cr *contestResult) RollB() Roll {
+	return cr.rollB
+}
+
+// Ties returns the tied rolls that were re-rolled, with side A's roll first in each pair.
+func (cr *contestResult) Ties() [][2]Roll {
+	return cr.ties
+}
+
+// Winner returns the side that won the contest.
+func (cr *contestResult) Winner() ContestSide {
+	return cr.winner
+}
+
+// AWins returns `true` if side A won the contest; `false` otherwise
+func (cr *contestResult) AWins() bool {
+	return cr.winner == ContestSideA
+}
+
+// IsTie returns `true` if both sides rolled the same value in the final roll; `false` otherwise
+func (cr *contestResult) IsTie() bool {
+	return cr.rollA.Value() == cr.rollB.Value()
+}
+
+// Margin returns the amount by which the winning roll exceeded the losing roll. This is zero if the
+// contest was decided by the tie rule.
+func (cr *contestResult) Margin() int {
+	if cr.winner == ContestSideA {
+		return cr.rollA.Value() - cr.rollB.Value()
+	}
+	return cr.rollB.Value() - cr.rollA.Value()
+}
+
+// ProbabilityAWins returns the exact probability that side A wins the contest.
+func (cr *contestResult) ProbabilityAWins() float64 {
+	return cr.contest.probabilityAWins()
+}
+
+// String returns a string representation of the contest, including both rolls and the winner.
+func (cr *contestResult) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(cr.rollA.String())
+	sb.WriteString(" vs ")
+	sb.WriteString(cr.rollB.String())
+	sb.WriteString(": ")
+	sb.WriteString(cr.winner.String())
+	sb.WriteString(" wins")
+	if cr.IsTie() {
+		sb.WriteString(" (Tie)")
+	} else {
+		sb.WriteString(" by ")
+		sb.WriteString(strconv.Itoa(cr.Margin()))
+	}
+
+	return sb.String()
+}
+
+// String returns a string representation of the contest side.
+func (s ContestSide) String() string {
+	switch s {
+	case ContestSideA:
+		return "A"
+	case ContestSideB:
+		return "B"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,123 @@
+package dice
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestContest tests rolling a contest between two dice
+func TestContest(t *testing.T) {
+	a := NewDice(1, 20, WithModifier(3))
+	b := NewDice(1, 20, WithModifier(1))
+
+	for i := 0; i < 100; i++ {
+		result := Contest(a, b, WithRollOptionsA(WithAdvantage()))
+		if !result.RollA().RolledWithAdvantage() {
+			t.Errorf("Expected side A to roll with advantage")
+		}
+		if result.RollB().RolledWithAdvantage() {
+			t.Errorf("Expected side B to not roll with advantage")
+		}
+
+		aValue, bValue := result.RollA().Value(), result.RollB().Value()
+		switch {
+		case aValue > bValue:
+			if !result.AWins() || result.Margin() != aValue-bValue {
+				t.Errorf("Expected side A to win by %d, got %s", aValue-bValue, result)
+			}
+		case aValue < bValue:
+			if result.Winner() != ContestSideB || result.Margin() != bValue-aValue {
+				t.Errorf("Expected side B to win by %d, got %s", bValue-aValue, result)
+			}
+		default:
+			if result.Winner() != ContestSideB || !result.IsTie() || result.Margin() != 0 {
+				t.Errorf("Expected defender to win the tie, got %s", result)
+			}
+		}
+	}
+}
+
+// TestContestTieRules tests resolving a tie in a contest
+func TestContestTieRules(t *testing.T) {
+	a := NewConstant(5)
+	b := NewConstant(5)
+
+	result := Contest(a, b)
+	if result.AWins() || !result.IsTie() {
+		t.Errorf("Expected defender to win a tie, got %s", result)
+	}
+
+	for i := 0; i < 100; i++ {
+		result = Contest(NewDice(1, 2, WithModifier(4)), NewDice(1, 2, WithModifier(3)), WithTieRule(TieHigherModifierWins))
+		if result.IsTie() && !result.AWins() {
+			t.Errorf("Expected side with the higher modifier to win a tie, got %s", result)
+		}
+	}
+
+	// Two constants can never break a tie, so the re-rolls are capped
+	result = Contest(a, b, WithTieRule(TieReroll))
+	if len(result.Ties()) != MaxContestRerolls {
+		t.Errorf("Expected %d re-rolls, got %d", MaxContestRerolls, len(result.Ties()))
+	}
+	if result.AWins() {
+		t.Errorf("Expected defender to win after the maximum re-rolls, got %s", result)
+	}
+
+	for i := 0; i < 100; i++ {
+		result = Contest(D4, D4, WithTieRule(TieReroll))
+		if result.IsTie() {
+			t.Errorf("Expected tie to be re-rolled, got %s", result)
+		}
+		for _, tie := range result.Ties() {
+			if tie[0].Value() != tie[1].Value() {
+				t.Errorf("Expected re-rolled rolls to be a tie, got %s and %s", tie[0], tie[1])
+			}
+		}
+	}
+}
+
+// TestContestProbability tests the exact probability of side A winning a contest
+func TestContestProbability(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        Dice
+		b        Dice
+		opts     []ContestOption
+		expected float64
+	}{
+		{"d20 vs d20", D20, D20, nil, 190.0 / 400.0},
+		{"d20 vs d20 re-roll ties", D20, D20, []ContestOption{WithTieRule(TieReroll)}, 0.5},
+		{"d6+1 vs d6 higher modifier", NewDice(1, 6, WithModifier(1)), D6,
+			[]ContestOption{WithTieRule(TieHigherModifierWins)}, 26.0 / 36.0},
+		{"d6 vs d6+1 higher modifier", D6, NewDice(1, 6, WithModifier(1)),
+			[]ContestOption{WithTieRule(TieHigherModifierWins)}, 10.0 / 36.0},
+		{"constant", NewConstant(6), D6, nil, 5.0 / 6.0},
+		{"advantage", D6, NewConstant(3), []ContestOption{WithRollOptionsA(WithAdvantage())}, 27.0 / 36.0},
+		{"disadvantage", D6, NewConstant(3), []ContestOption{WithRollOptionsA(WithDisadvantage())}, 9.0 / 36.0},
+		{"tied constants re-roll", NewConstant(5), NewConstant(5), []ContestOption{WithTieRule(TieReroll)}, 0},
+	}
+
+	for _, test := range tests {
+		p := ContestProbability(test.a, test.b, test.opts...)
+		if !almostEqual(p, test.expected) {
+			t.Errorf("%s: expected probability %f, got %f", test.name, test.expected, p)
+		}
+		result := Contest(test.a, test.b, test.opts...)
+		if !almostEqual(result.ProbabilityAWins(), test.expected) {
+			t.Errorf("%s: expected result probability %f, got %f", test.name, test.expected, result.ProbabilityAWins())
+		}
+	}
+}
+
+// TestContestString tests the string representation of a contest
+func TestContestString(t *testing.T) {
+	result := Contest(NewConstant(7), NewConstant(4))
+	if !strings.HasSuffix(result.String(), "A wins by 3") {
+		t.Errorf("Expected side A to win by 3, got `%s`", result)
+	}
+
+	result = Contest(NewConstant(4), NewConstant(4))
+	if !strings.HasSuffix(result.String(), "B wins (Tie)") {
+		t.Errorf("Expected side B to win the tie, got `%s`", result)
+	}
+}
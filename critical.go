@@ -0,0 +1,59 @@
+package dice
+
+// CriticalDamageRule is the rule used to calculate the damage for a critical hit.
+type CriticalDamageRule int
+
+const (
+	_                   CriticalDamageRule = iota
+	CriticalDoubleDice                     // The damage dice are rolled twice; modifiers are not doubled
+	CriticalMaxPlusRoll                    // One set of damage dice is maximized and another is rolled; modifiers are not doubled
+	CriticalTimesTwo                       // The total damage, including modifiers, is multiplied by two
+	CriticalTimesThree                     // The total damage, including modifiers, is multiplied by three
+)
+
+// WithCriticalDamage rolls the damage for a critical hit using the rule. When rolling a dice set, the
+// rule applies to every dice in the set. Rules that only affect the damage dice leave constant values,
+// such as those created with NewConstant, unchanged.
+func WithCriticalDamage(rule CriticalDamageRule) RollOption {
+	return func(r *roll) {
+		r.criticalDamage = rule
+	}
+}
+
+// affects returns true if the rule changes the value rolled for the dice.
+func (rule CriticalDamageRule) affects(d Dice) bool {
+	switch rule {
+	case CriticalDoubleDice, CriticalMaxPlusRoll:
+		return !d.IsConstant()
+	case CriticalTimesTwo, CriticalTimesThree:
+		return true
+	default:
+		return false
+	}
+}
+
+// isCriticalDamage returns `true` if the roll was changed by a critical damage rule; `false` otherwise
+func (r *roll) isCriticalDamage() bool {
+	return r.criticalDamage.affects(r.dice)
+}
+
+// isCriticalDamage returns `true` if the roll was changed by a critical damage rule; `false` otherwise
+func (r *singleRoll) isCriticalDamage() bool {
+	return r.criticalDamage.affects(r.dice)
+}
+
+// String returns a string representation of the critical damage rule.
+func (rule CriticalDamageRule) String() string {
+	switch rule {
+	case CriticalDoubleDice:
+		return "Double Dice"
+	case CriticalMaxPlusRoll:
+		return "Max Plus Roll"
+	case CriticalTimesTwo:
+		return "x2"
+	case CriticalTimesThree:
+		return "x3"
+	default:
+		return "None"
+	}
+}
@@ -0,0 +1,128 @@
+package dice
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCriticalDamageDice tests rolling critical damage for a single dice
+func TestCriticalDamageDice(t *testing.T) {
+	d := ParseDice("2d6+3")
+
+	tests := []struct {
+		rule     CriticalDamageRule
+		minValue int
+		maxValue int
+		multiple int
+	}{
+		{CriticalDoubleDice, 7, 27, 1},
+		{CriticalMaxPlusRoll, 17, 27, 1},
+		{CriticalTimesTwo, 10, 30, 2},
+		{CriticalTimesThree, 15, 45, 3},
+	}
+
+	for _, test := range tests {
+		for i := 0; i < 100; i++ {
+			r := d.Roll(WithCriticalDamage(test.rule))
+			if r.Value() < test.minValue || r.Value() > test.maxValue {
+				t.Errorf("%s: expected value between %d and %d, got %d", test.rule, test.minValue, test.maxValue, r.Value())
+			}
+			if r.Value()%test.multiple != 0 {
+				t.Errorf("%s: expected value to be a multiple of %d, got %d", test.rule, test.multiple, r.Value())
+			}
+			if !strings.Contains(r.String(), "(2d6+3) (Critical!)") {
+				t.Errorf("%s: expected roll to be annotated as critical, got `%s`", test.rule, r)
+			}
+		}
+	}
+
+	// A debuff is negated after the critical damage is applied
+	r := ParseDice("1d4+1", AsDebuff()).Roll(WithCriticalDamage(CriticalMaxPlusRoll))
+	if r.Value() < -9 || r.Value() > -6 {
+		t.Errorf("Expected critical debuff between -9 and -6, got %d", r.Value())
+	}
+}
+
+// TestCriticalDamageConstant tests that constants are only changed by rules that multiply the total
+func TestCriticalDamageConstant(t *testing.T) {
+	c := NewConstant(4, WithSource("Strength"))
+
+	for _, rule := range []CriticalDamageRule{CriticalDoubleDice, CriticalMaxPlusRoll} {
+		r := c.Roll(WithCriticalDamage(rule))
+		if r.Value() != 4 {
+			t.Errorf("%s: expected constant to be 4, got %d", rule, r.Value())
+		}
+		if strings.Contains(r.String(), "Critical!") {
+			t.Errorf("%s: expected constant to not be annotated as critical, got `%s`", rule, r)
+		}
+	}
+
+	r := c.Roll(WithCriticalDamage(CriticalTimesThree))
+	if r.Value() != 12 {
+		t.Errorf("Expected constant multiplied by 3 to be 12, got %d", r.Value())
+	}
+	if r.String() != "12 (Strength) (Critical!) = 12" {
+		t.Errorf("Expected `12 (Strength) (Critical!) = 12`, got `%s`", r)
+	}
+}
+
+// TestCriticalDamageDiceSet tests rolling critical damage for a dice set
+func TestCriticalDamageDiceSet(t *testing.T) {
+	ds := NewDiceSet(
+		ParseDice("1d8", WithSource("Longsword")),
+		ParseDice("2d6", WithSource("Sneak Attack")),
+		NewConstant(3, WithSource("Strength")),
+	)
+
+	for i := 0; i < 100; i++ {
+		r := ds.Roll(WithCriticalDamage(CriticalDoubleDice))
+		if r.Value() < 9 || r.Value() > 43 {
+			t.Errorf("Expected critical damage between 9 and 43, got %d", r.Value())
+		}
+
+		rolls := r.GetAllRolls()
+		if rolls[0].Value() < 2 || rolls[0].Value() > 16 || rolls[1].Value() < 4 || rolls[1].Value() > 24 {
+			t.Errorf("Expected all damage dice to be doubled, got `%s`", r)
+		}
+		if rolls[2].Value() != 3 {
+			t.Errorf("Expected constant to be unchanged, got %d", rolls[2].Value())
+		}
+		if strings.Count(r.String(), "(Critical!)") != 2 {
+			t.Errorf("Expected two terms to be annotated as critical, got `%s`", r)
+		}
+	}
+
+	r := ds.Roll(WithCriticalDamage(CriticalTimesTwo))
+	if r.Value()%2 != 0 || strings.Count(r.String(), "(Critical!)") != 3 {
+		t.Errorf("Expected all terms to be doubled, got `%s`", r)
+	}
+
+	// Without a critical damage rule, no terms are annotated
+	r = ds.Roll()
+	if strings.Contains(r.String(), "Critical!") {
+		t.Errorf("Expected no terms to be annotated as critical, got `%s`", r)
+	}
+}
+
+// TestCriticalDamageOnCriticalHit tests that a critical hit rolled with a critical damage rule is only
+// annotated as critical once
+func TestCriticalDamageOnCriticalHit(t *testing.T) {
+	criticals := 0
+	for range 2000 {
+		r := D20.Roll(WithCriticalHitAllowed(), WithCriticalDamage(CriticalTimesTwo))
+		if strings.Count(r.String(), "(Critical!)") != 1 {
+			t.Fatalf("Expected the roll to be annotated as critical once, got `%s`", r)
+		}
+		if r.IsCriticalHit() {
+			criticals++
+			for _, single := range r.GetAllRolls() {
+				if strings.Count(single.String(), "(Critical!)") != 1 {
+					t.Fatalf("Expected the single roll to be annotated as critical once, got `%s`", single)
+				}
+			}
+		}
+	}
+	if criticals == 0 {
+		t.Errorf("Expected some critical hits")
+	}
+}
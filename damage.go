@@ -0,0 +1,367 @@
+package dice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DamageType is the type of damage dealt by a dice, such as fire or slashing.
+type DamageType string
+
+// Pre-defined damage types
+const (
+	Acid        DamageType = "acid"
+	Bludgeoning DamageType = "bludgeoning"
+	Cold        DamageType = "cold"
+	Fire        DamageType = "fire"
+	Force       DamageType = "force"
+	Lightning   DamageType = "lightning"
+	Necrotic    DamageType = "necrotic"
+	Piercing    DamageType = "piercing"
+	Poison      DamageType = "poison"
+	Psychic     DamageType = "psychic"
+	Radiant     DamageType = "radiant"
+	Slashing    DamageType = "slashing"
+	Thunder     DamageType = "thunder"
+)
+
+// Rounding is the rule used to round damage that is divided, such as when it is halved.
+type Rounding int
+
+const (
+	RoundDown Rounding = iota // Fractions are rounded down
+	RoundUp                   // Fractions are rounded up
+)
+
+// Defense is the defense a creature has against a type of damage.
+type Defense int
+
+const (
+	NoDefense                  Defense = iota // The damage is taken as normal
+	Resistance                                // The damage is halved
+	Immunity                                  // No damage is taken
+	Vulnerability                             // The damage is doubled
+	ResistanceAndVulnerability                // The damage is halved and then doubled
+)
+
+// Defenses are the resistances, immunities and vulnerabilities a creature has to types of damage.
+type Defenses interface {
+	Resistances() []DamageType                              // The damage types the creature is resistant to
+	Immunities() []DamageType                               // The damage types the creature is immune to
+	Vulnerabilities() []DamageType                          // The damage types the creature is vulnerable to
+	Rounding() Rounding                                     // The rounding used when damage is halved
+	Apply(damageType DamageType, damage int) (int, Defense) // Applies the defenses to the damage of a single type
+}
+
+// DamageTerm is the damage of a single type after defenses are applied.
+type DamageTerm interface {
+	DamageType() DamageType // The type of damage
+	Rolled() int            // The damage that was rolled
+	Value() int             // The damage after defenses are applied
+	Defense() Defense       // The defense that was applied to the damage
+	Rolls() []Roll          // The rolls that dealt this type of damage
+	fmt.Stringer            // String representation of the damage term
+}
+
+// DamageResult is the damage dealt by a roll after defenses are applied.
+type DamageResult interface {
+	Roll() Roll          // The damage roll
+	Terms() []DamageTerm // The damage for each type, in the order the types were rolled
+	Rolled() int         // The total damage that was rolled
+	Total() int          // The total damage after defenses are applied
+	fmt.Stringer         // String representation of the damage
+}
+
+// defenses is an implementation of the Defenses interface.
+type defenses struct {
+	resistances     []DamageType // The damage types the creature is resistant to
+	immunities      []DamageType // The damage types the creature is immune to
+	vulnerabilities []DamageType // The damage types the creature is vulnerable to
+	rounding        Rounding     // The rounding used when damage is halved
+}
+
+// damageTerm is an implementation of the DamageTerm interface.
+type damageTerm struct {
+	damageType DamageType // The type of damage
+	rolled     int        // The damage that was rolled
+	value      int        // The damage after defenses are applied
+	defense    Defense    // The defense that was applied to the damage
+	rolls      []Roll     // The rolls that dealt this type of damage
+}
+
+// damageResult is an implementation of the DamageResult interface.
+type damageResult struct {
+	roll  Roll          // The damage roll
+	terms []*damageTerm // The damage for each type
+}
+
+// DefenseOption is a function that can modify the default values of a creature's defenses.
+type DefenseOption func(*defenses)
+
+// WithDamageType sets the type of damage dealt by the dice.
+func WithDamageType(damageType DamageType) DiceOption {
+	return func(d *dice) {
+		d.damageType = damageType
+	}
+}
+
+// NewDefenses creates the defenses a creature has against types of damage. By default, halved damage
+// is rounded down.
+func NewDefenses(opts ...DefenseOption) Defenses {
+	d := &defenses{
+		rounding: RoundDown,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// WithResistance adds resistance to the damage types, halving the damage taken.
+func WithResistance(damageTypes ...DamageType) DefenseOption {
+	return func(d *defenses) {
+		d.resistances = append(d.resistances, damageTypes...)
+	}
+}
+
+// WithImmunity adds immunity to the damage types, preventing all damage.
+func WithImmunity(damageTypes ...DamageType) DefenseOption {
+	return func(d *defenses) {
+		d.immunities = append(d.immunities, damageTypes...)
+	}
+}
+
+// WithVulnerability adds vulnerability to the damage types, doubling the damage taken.
+func WithVulnerability(damageTypes ...DamageType) DefenseOption {
+	return func(d *defenses) {
+		d.vulnerabilities = append(d.vulnerabilities, damageTypes...)
+	}
+}
+
+// WithRounding sets the rounding used when damage is halved.
+func WithRounding(rounding Rounding) DefenseOption {
+	return func(d *defenses) {
+		d.rounding = rounding
+	}
+}
+
+// ApplyDefenses applies the defenses to the damage roll. The damage from each term in a dice set is
+// totalled by damage type before the defenses are applied. Untyped damage is not affected by the
+// defenses, and the damage for each type is never less than zero.
+func ApplyDefenses(r Roll, defenses Defenses) DamageResult {
+	dr := &damageResult{
+		roll: r,
+	}
+
+	var rolls []Roll
+	if rs, ok := r.(rollSet); ok {
+		rolls = rs
+	} else {
+		rolls = []Roll{r}
+	}
+
+	for _, roll := range rolls {
+		damageType := roll.GetDice().DamageType()
+		term := dr.term(damageType)
+		if term == nil {
+			term = &damageTerm{
+				damageType: damageType,
+			}
+			dr.terms = append(dr.terms, term)
+		}
+		term.rolled += roll.Value()
+		term.rolls = append(term.rolls, roll)
+	}
+
+	for _, term := range dr.terms {
+		term.rolled = max(term.rolled, 0)
+		term.value, term.defense = defenses.Apply(term.damageType, term.rolled)
+	}
+
+	return dr
+}
+
+// Resistances returns the damage types the creature is resistant to.
+func (d *defenses) Resistances() []DamageType {
+	return d.resistances
+}
+
+// Immunities returns the damage types the creature is immune to.
+func (d *defenses) Immunities() []DamageType {
+	return d.immunities
+}
+
+// Vulnerabilities returns the damage types the creature is vulnerable to.
+func (d *defenses) Vulnerabilities() []DamageType {
+	return d.vulnerabilities
+}
+
+// Rounding returns the rounding used when damage is halved.
+func (d *defenses) Rounding() Rounding {
+	return d.rounding
+}
+
+// Apply applies the defenses to damage of a single type, returning the damage taken and the defense
+// that was applied. Immunity takes precedence over resistance and vulnerability. A creature that is
+// both resistant and vulnerable to a type of damage halves the damage before doubling it, and both
+// defenses are reported as ResistanceAndVulnerability.
+func (d *defenses) Apply(damageType DamageType, damage int) (int, Defense) {
+	if damageType == "" {
+		return damage, NoDefense
+	}
+
+	resistant := containsDamageType(d.resistances, damageType)
+	vulnerable := containsDamageType(d.vulnerabilities, damageType)
+	switch {
+	case containsDamageType(d.immunities, damageType):
+		return 0, Immunity
+	case resistant && vulnerable:
+		return HalveDamage(damage, d.rounding) * 2, ResistanceAndVulnerability
+	case resistant:
+		return HalveDamage(damage, d.rounding), Resistance
+	case vulnerable:
+		return damage * 2, Vulnerability
+	default:
+		return damage, NoDefense
+	}
+}
+
+// HalveDamage returns half of the damage, rounded using the rounding rule.
+func HalveDamage(damage int, rounding Rounding) int {
+	if rounding == RoundUp {
+		return (damage + 1) / 2
+	}
+	return damage / 2
+}
+
+// containsDamageType returns true if the damage type is included in the damage types.
+func containsDamageType(damageTypes []DamageType, damageType DamageType) bool {
+	for _, dt := range damageTypes {
+		if dt == damageType {
+			return true
+		}
+	}
+	return false
+}
+
+// term returns the damage term for the damage type, or nil if there is none.
+func (dr *damageResult) term(damageType DamageType) *damageTerm {
+	for _, term := range dr.terms {
+		if term.damageType == damageType {
+			return term
+		}
+	}
+	return nil
+}
+
+// Roll returns the damage roll.
+func (dr *damageResult) Roll() Roll {
+	return dr.roll
+}
+
+// Terms returns the damage for each type, in the order the types were rolled.
+func (dr *damageResult) Terms() []DamageTerm {
+	terms := make([]DamageTerm, 0, len(dr.terms))
+	for _, term := range dr.terms {
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// Rolled returns the total damage that was rolled.
+func (dr *damageResult) Rolled() int {
+	var total int
+	for _, term := range dr.terms {
+		total += term.rolled
+	}
+	return total
+}
+
+// Total returns the total damage after defenses are applied.
+func (dr *damageResult) Total() int {
+	var total int
+	for _, term := range dr.terms {
+		total += term.value
+	}
+	return total
+}
+
+// DamageType returns the type of damage.
+func (dt *damageTerm) DamageType() DamageType {
+	return dt.damageType
+}
+
+// Rolled returns the damage that was rolled.
+func (dt *damageTerm) Rolled() int {
+	return dt.rolled
+}
+
+// Value returns the damage after defenses are applied.
+func (dt *damageTerm) Value() int {
+	return dt.value
+}
+
+// Defense returns the defense that was applied to the damage.
+func (dt *damageTerm) Defense() Defense {
+	return dt.defense
+}
+
+// Rolls returns the rolls that dealt this type of damage.
+func (dt *damageTerm) Rolls() []Roll {
+	return dt.rolls
+}
+
+// String returns a string representation of the defense.
+func (d Defense) String() string {
+	switch d {
+	case Resistance:
+		return "resisted"
+	case Immunity:
+		return "immune"
+	case Vulnerability:
+		return "vulnerable"
+	case ResistanceAndVulnerability:
+		return "resisted, vulnerable"
+	default:
+		return "none"
+	}
+}
+
+// String returns a string representation of the damage term, such as `7 fire (resisted → 3)`.
+func (dt *damageTerm) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(strconv.Itoa(dt.rolled))
+	if dt.damageType != "" {
+		sb.WriteString(" ")
+		sb.WriteString(string(dt.damageType))
+	}
+	if dt.defense != NoDefense {
+		sb.WriteString(" (")
+		sb.WriteString(dt.defense.String())
+		sb.WriteString(" → ")
+		sb.WriteString(strconv.Itoa(dt.value))
+		sb.WriteString(")")
+	}
+
+	return sb.String()
+}
+
+// String returns a string representation of the damage, with the breakdown for each damage type and
+// the total damage taken.
+func (dr *damageResult) String() string {
+	var sb strings.Builder
+
+	for i, term := range dr.terms {
+		if i > 0 {
+			sb.WriteString(" + ")
+		}
+		sb.WriteString(term.String())
+	}
+	sb.WriteString(" = ")
+	sb.WriteString(strconv.Itoa(dr.Total()))
+
+	return sb.String()
+}
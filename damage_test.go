@@ -0,0 +1,139 @@
+package dice
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDamageType tests setting the damage type of a dice
+func TestDamageType(t *testing.T) {
+	d := ParseDice("2d6", WithDamageType(Fire), WithSource("Fireball"))
+	if d.DamageType() != Fire {
+		t.Errorf("Expected damage type to be fire, got %s", d.DamageType())
+	}
+	if d.String() != "2d6 fire (Fireball)" {
+		t.Errorf("Expected `2d6 fire (Fireball)`, got `%s`", d.String())
+	}
+	if !strings.Contains(d.Roll().String(), "(2d6 fire, Fireball)") {
+		t.Errorf("Expected roll to include the damage type, got `%s`", d.Roll())
+	}
+
+	c := NewConstant(3, WithDamageType(Slashing))
+	if c.Roll().String() != "3 (slashing) = 3" {
+		t.Errorf("Expected `3 (slashing) = 3`, got `%s`", c.Roll())
+	}
+
+	ds := NewDiceSet(ParseDice("1d8", WithDamageType(Slashing)), NewConstant(3, WithDamageType(Slashing)))
+	if ds.DamageType() != Slashing {
+		t.Errorf("Expected dice set to deal slashing damage, got %s", ds.DamageType())
+	}
+	ds = NewDiceSet(ParseDice("1d8", WithDamageType(Slashing)), ParseDice("1d6", WithDamageType(Fire)))
+	if ds.DamageType() != "" {
+		t.Errorf("Expected mixed dice set to have no damage type, got %s", ds.DamageType())
+	}
+}
+
+// TestHalveDamage tests halving damage with each rounding rule
+func TestHalveDamage(t *testing.T) {
+	tests := []struct {
+		damage   int
+		rounding Rounding
+		expected int
+	}{
+		{7, RoundDown, 3},
+		{7, RoundUp, 4},
+		{8, RoundDown, 4},
+		{8, RoundUp, 4},
+		{1, RoundDown, 0},
+		{0, RoundUp, 0},
+	}
+
+	for _, test := range tests {
+		if value := HalveDamage(test.damage, test.rounding); value != test.expected {
+			t.Errorf("HalveDamage(%d, %d) = %d; expected %d", test.damage, test.rounding, value, test.expected)
+		}
+	}
+}
+
+// TestDefensesApply tests applying defenses to damage of a single type
+func TestDefensesApply(t *testing.T) {
+	defenses := NewDefenses(
+		WithResistance(Fire, Cold),
+		WithImmunity(Poison, Cold),
+		WithVulnerability(Radiant, Fire),
+		WithResistance(Slashing),
+	)
+
+	tests := []struct {
+		damageType DamageType
+		damage     int
+		expected   int
+		defense    Defense
+	}{
+		{Slashing, 7, 3, Resistance},
+		{Poison, 12, 0, Immunity},
+		{Cold, 12, 0, Immunity},
+		{Radiant, 5, 10, Vulnerability},
+		{Fire, 7, 6, ResistanceAndVulnerability},
+		{Piercing, 7, 7, NoDefense},
+		{"", 7, 7, NoDefense},
+	}
+
+	for _, test := range tests {
+		value, defense := defenses.Apply(test.damageType, test.damage)
+		if value != test.expected || defense != test.defense {
+			t.Errorf("Apply(%s, %d) = %d, %s; expected %d, %s",
+				test.damageType, test.damage, value, defense, test.expected, test.defense)
+		}
+	}
+}
+
+// TestApplyDefenses tests applying defenses to a damage roll
+func TestApplyDefenses(t *testing.T) {
+	r := NewDiceSet(
+		NewConstant(5, WithDamageType(Slashing)),
+		NewConstant(7, WithDamageType(Fire)),
+		NewConstant(2, WithDamageType(Slashing)),
+		NewConstant(4),
+	).Roll()
+
+	result := ApplyDefenses(r, NewDefenses(WithResistance(Fire)))
+	if result.Rolled() != 18 || result.Total() != 14 {
+		t.Errorf("Expected 18 damage rolled and 14 taken, got %d and %d", result.Rolled(), result.Total())
+	}
+
+	terms := result.Terms()
+	if len(terms) != 3 {
+		t.Fatalf("Expected 3 damage terms, got %d", len(terms))
+	}
+	if terms[0].DamageType() != Slashing || terms[0].Value() != 7 || len(terms[0].Rolls()) != 2 {
+		t.Errorf("Expected slashing damage to be totalled, got %s", terms[0])
+	}
+
+	expected := "7 slashing + 7 fire (resisted → 3) + 4 = 14"
+	if result.String() != expected {
+		t.Errorf("Expected `%s`, got `%s`", expected, result.String())
+	}
+
+	// Both defenses are reported when a creature is resistant and vulnerable to a type
+	result = ApplyDefenses(r, NewDefenses(WithResistance(Fire), WithVulnerability(Fire)))
+	expected = "7 slashing + 7 fire (resisted, vulnerable → 6) + 4 = 17"
+	if result.String() != expected || result.Terms()[1].Defense() != ResistanceAndVulnerability {
+		t.Errorf("Expected `%s`, got `%s`", expected, result.String())
+	}
+
+	result = ApplyDefenses(r, NewDefenses(WithResistance(Fire, Slashing), WithRounding(RoundUp)))
+	if result.Total() != 12 {
+		t.Errorf("Expected 12 damage when rounding up, got %d (%s)", result.Total(), result)
+	}
+
+	// A single dice is a single damage term
+	single := ParseDice("2d6", WithDamageType(Fire)).Roll()
+	result = ApplyDefenses(single, NewDefenses(WithImmunity(Fire)))
+	if len(result.Terms()) != 1 || result.Total() != 0 || result.Terms()[0].Defense() != Immunity {
+		t.Errorf("Expected immunity to fire to prevent all damage, got %s", result)
+	}
+	if result.Roll() != single {
+		t.Errorf("Expected result to include the damage roll")
+	}
+}
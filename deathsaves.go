@@ -0,0 +1,272 @@
+package dice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DeathSaveStatus is the status of a creature that is making death saving throws.
+type DeathSaveStatus int
+
+const (
+	_                DeathSaveStatus = iota
+	DeathSaveDying                   // The creature is dying and must continue to make death saving throws
+	DeathSaveStable                  // The creature is stable and no longer makes death saving throws
+	DeathSaveDead                    // The creature has died
+	DeathSaveRevived                 // The creature has regained hit points and is conscious
+)
+
+const (
+	DeathSaveDC           = 10 // The value a death saving throw must meet or exceed to succeed
+	DeathSavesToStabilize = 3  // The number of successes needed to become stable
+	DeathSavesToDie       = 3  // The number of failures that result in death
+)
+
+// DeathSaves tracks the death saving throws made by a creature at 0 hit points.
+type DeathSaves interface {
+	Roll(opts ...RollOption) Roll                           // Rolls a death saving throw with a D20 and records it
+	Record(r Roll) DeathSaveStatus                          // Records a death saving throw that has already been rolled
+	TakeDamage(damage int, isCritical bool) DeathSaveStatus // Records damage taken while at 0 hit points
+	Stabilize() DeathSaveStatus                             // Stabilizes the creature, such as with a Medicine check
+	Heal(hitPoints int) DeathSaveStatus                     // Restores hit points to the creature, reviving it
+	Reset()                                                 // Resets the tracker for a creature that has dropped to 0 hit points
+	Status() DeathSaveStatus                                // The current status of the creature
+	Successes() int                                         // The number of successful death saving throws
+	Failures() int                                          // The number of failed death saving throws
+	HitPoints() int                                         // The hit points regained by the creature, if it was revived
+	Rolls() []Roll                                          // The death saving throws that were rolled, in the order they were rolled
+	fmt.Stringer                                            // String representation of the death saving throws
+}
+
+// deathSaves is an implementation of the DeathSaves interface.
+type deathSaves struct {
+	status       DeathSaveStatus // The current status of the creature
+	successes    int             // The number of successful death saving throws
+	failures     int             // The number of failed death saving throws
+	hitPoints    int             // The hit points regained by the creature
+	maxHitPoints int             // The hit point maximum, used to determine death from massive damage
+	rolls        []Roll          // The death saving throws that were rolled
+}
+
+// DeathSavesOption is a function that can modify the default values of a death saving throw tracker.
+type DeathSavesOption func(*deathSaves)
+
+// NewDeathSaves creates a new tracker for a creature that has dropped to 0 hit points.
+func NewDeathSaves(opts ...DeathSavesOption) DeathSaves {
+	ds := &deathSaves{
+		status: DeathSaveDying,
+		rolls:  make([]Roll, 0, DeathSavesToStabilize+DeathSavesToDie),
+	}
+	for _, opt := range opts {
+		opt(ds)
+	}
+
+	return ds
+}
+
+// WithMaxHitPoints sets the hit point maximum of the creature. Taking damage while at 0 hit points
+// that equals or exceeds the hit point maximum results in instant death.
+func WithMaxHitPoints(hitPoints int) DeathSavesOption {
+	return func(ds *deathSaves) {
+		ds.maxHitPoints = hitPoints
+	}
+}
+
+// Roll rolls a death saving throw using a D20 and records the result.
+func (ds *deathSaves) Roll(opts ...RollOption) Roll {
+	r := D20.Roll(opts...)
+	ds.Record(r)
+	return r
+}
+
+// Record records a death saving throw. A natural 20 revives the creature with 1 hit point, and a
+// natural 1 counts as two failures. If the save was rolled with other dice, such as a d20 and a d4 from
+// bless, the natural roll is the roll of the d20. Otherwise, the save succeeds if it meets or exceeds DeathSaveDC.
+// Saves recorded when the creature is not dying are ignored.
+func (ds *deathSaves) Record(r Roll) DeathSaveStatus {
+	if ds.status != DeathSaveDying {
+		return ds.status
+	}
+	ds.rolls = append(ds.rolls, r)
+
+	natural := naturalDeathSave(r)
+	switch {
+	case natural == CriticalHit:
+		return ds.Heal(1)
+	case natural == CriticalMiss:
+		ds.addFailures(2)
+	case r.Value() >= DeathSaveDC:
+		ds.successes++
+		if ds.successes >= DeathSavesToStabilize {
+			ds.status = DeathSaveStable
+		}
+	default:
+		ds.addFailures(1)
+	}
+
+	return ds.status
+}
+
+// naturalDeathSave returns the value rolled on the d20 of a death saving throw, without modifiers. If
+// other dice were rolled with the d20, such as a d4 from bless, only the roll of the d20 is used.
+func naturalDeathSave(r Roll) int {
+	if rs, ok := r.(rollSet); ok && len(rs) > 0 {
+		for _, roll := range rs {
+			if roll.GetDice().NumDice() == 1 && roll.GetDice().NumSides() == 20 {
+				return naturalDeathSave(roll)
+			}
+		}
+		return naturalDeathSave(rs[0])
+	}
+	return r.Value() - totalModifier(r.GetDice())
+}
+
+// TakeDamage records damage taken while at 0 hit points. Damage causes one failure, or two if it
+// is from a critical hit. Damage that equals or exceeds the hit point maximum, if one is set, kills
+// the creature outright. A stable creature that takes damage starts dying again, and a revived
+// creature loses its regained hit points before dropping back to 0 hit points.
+func (ds *deathSaves) TakeDamage(damage int, isCritical bool) DeathSaveStatus {
+	switch ds.status {
+	case DeathSaveDead:
+		return ds.status
+	case DeathSaveStable:
+		ds.status = DeathSaveDying
+		ds.successes = 0
+		ds.failures = 0
+	case DeathSaveRevived:
+		// Damage is taken from the regained hit points before the creature drops to 0 again
+		if damage < ds.hitPoints {
+			ds.hitPoints -= damage
+			return ds.status
+		}
+		// Dropping back to 0 hit points does not cause a failure, unless it is massive damage
+		damage -= ds.hitPoints
+		ds.Reset()
+		if ds.maxHitPoints > 0 && damage >= ds.maxHitPoints {
+			ds.status = DeathSaveDead
+		}
+		return ds.status
+	}
+
+	if ds.maxHitPoints > 0 && damage >= ds.maxHitPoints {
+		ds.status = DeathSaveDead
+		return ds.status
+	}
+	if isCritical {
+		ds.addFailures(2)
+	} else {
+		ds.addFailures(1)
+	}
+
+	return ds.status
+}
+
+// Stabilize stabilizes a dying creature, which no longer needs to make death saving throws.
+func (ds *deathSaves) Stabilize() DeathSaveStatus {
+	if ds.status == DeathSaveDying {
+		ds.status = DeathSaveStable
+	}
+	return ds.status
+}
+
+// Heal restores hit points to a creature that is not dead, reviving it. The successes and
+// failures are reset.
+func (ds *deathSaves) Heal(hitPoints int) DeathSaveStatus {
+	if ds.status == DeathSaveDead || hitPoints <= 0 {
+		return ds.status
+	}
+	ds.status = DeathSaveRevived
+	ds.hitPoints += hitPoints
+	ds.successes = 0
+	ds.failures = 0
+	return ds.status
+}
+
+// Reset resets the tracker for a creature that has dropped to 0 hit points. The log of the death
+// saving throws that were rolled is retained.
+func (ds *deathSaves) Reset() {
+	ds.status = DeathSaveDying
+	ds.successes = 0
+	ds.failures = 0
+	ds.hitPoints = 0
+}
+
+// addFailures adds the failures to the tracker, killing the creature if there are too many.
+func (ds *deathSaves) addFailures(failures int) {
+	ds.failures = min(ds.failures+failures, DeathSavesToDie)
+	if ds.failures >= DeathSavesToDie {
+		ds.status = DeathSaveDead
+	}
+}
+
+// Status returns the current status of the creature.
+func (ds *deathSaves) Status() DeathSaveStatus {
+	return ds.status
+}
+
+// Successes returns the number of successful death saving throws.
+func (ds *deathSaves) Successes() int {
+	return ds.successes
+}
+
+// Failures returns the number of failed death saving throws.
+func (ds *deathSaves) Failures() int {
+	return ds.failures
+}
+
+// HitPoints returns the hit points regained by the creature if it was revived.
+func (ds *deathSaves) HitPoints() int {
+	return ds.hitPoints
+}
+
+// Rolls returns the death saving throws that were rolled.
+func (ds *deathSaves) Rolls() []Roll {
+	return ds.rolls
+}
+
+// String returns a string representation of the status.
+func (s DeathSaveStatus) String() string {
+	switch s {
+	case DeathSaveDying:
+		return "Dying"
+	case DeathSaveStable:
+		return "Stable"
+	case DeathSaveDead:
+		return "Dead"
+	case DeathSaveRevived:
+		return "Revived"
+	default:
+		return "Unknown"
+	}
+}
+
+// String returns a string representation of the death saving throws. This includes the status,
+// the number of successes and failures, and each of the rolls.
+func (ds *deathSaves) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(ds.status.String())
+	sb.WriteString(" (")
+	sb.WriteString(strconv.Itoa(ds.successes))
+	sb.WriteString(" successes, ")
+	sb.WriteString(strconv.Itoa(ds.failures))
+	sb.WriteString(" failures")
+	if ds.status == DeathSaveRevived {
+		sb.WriteString(", ")
+		sb.WriteString(strconv.Itoa(ds.hitPoints))
+		sb.WriteString(" HP")
+	}
+	sb.WriteString(")")
+	if len(ds.rolls) > 0 {
+		sb.WriteString(": ")
+		for i, r := range ds.rolls {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(strconv.Itoa(r.Value()))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,168 @@
+package dice
+
+import "testing"
+
+// newTestDeathSave creates a death saving throw with a fixed value
+func newTestDeathSave(value int) Roll {
+	return &singleRoll{value: value, dice: D20}
+}
+
+// TestDeathSavesStabilize tests becoming stable after three successes
+func TestDeathSavesStabilize(t *testing.T) {
+	ds := NewDeathSaves()
+
+	for _, value := range []int{10, 5, 15} {
+		if status := ds.Record(newTestDeathSave(value)); status != DeathSaveDying {
+			t.Errorf("Expected creature to be dying after rolling %d, got %s", value, status)
+		}
+	}
+	if ds.Successes() != 2 || ds.Failures() != 1 {
+		t.Errorf("Expected 2 successes and 1 failure, got %d and %d", ds.Successes(), ds.Failures())
+	}
+
+	if status := ds.Record(newTestDeathSave(12)); status != DeathSaveStable {
+		t.Errorf("Expected creature to be stable, got %s", status)
+	}
+
+	// Saves are not recorded once the creature is stable
+	ds.Record(newTestDeathSave(1))
+	if len(ds.Rolls()) != 4 || ds.Failures() != 1 {
+		t.Errorf("Expected save to be ignored once stable, got %s", ds)
+	}
+}
+
+// TestDeathSavesDie tests dying after three failures
+func TestDeathSavesDie(t *testing.T) {
+	ds := NewDeathSaves()
+
+	ds.Record(newTestDeathSave(9))
+	if status := ds.Record(newTestDeathSave(1)); status != DeathSaveDead {
+		t.Errorf("Expected a natural 1 to count as two failures, got %s", ds)
+	}
+	if ds.Failures() != DeathSavesToDie {
+		t.Errorf("Expected %d failures, got %d", DeathSavesToDie, ds.Failures())
+	}
+
+	// A dead creature cannot be healed
+	if status := ds.Heal(10); status != DeathSaveDead {
+		t.Errorf("Expected creature to remain dead, got %s", status)
+	}
+}
+
+// TestDeathSavesNatural20 tests regaining a hit point on a natural 20
+func TestDeathSavesNatural20(t *testing.T) {
+	ds := NewDeathSaves()
+
+	ds.Record(newTestDeathSave(3))
+	ds.Record(newTestDeathSave(4))
+	if status := ds.Record(newTestDeathSave(20)); status != DeathSaveRevived {
+		t.Errorf("Expected a natural 20 to revive the creature, got %s", status)
+	}
+	if ds.HitPoints() != 1 {
+		t.Errorf("Expected creature to regain 1 hit point, got %d", ds.HitPoints())
+	}
+	if ds.Failures() != 0 || ds.Successes() != 0 {
+		t.Errorf("Expected successes and failures to be reset, got %s", ds)
+	}
+
+	// A modifier does not make a natural 20
+	ds = NewDeathSaves()
+	modified := NewDice(1, 20, WithModifier(2))
+	if status := ds.Record(&singleRoll{value: 20, dice: modified.(*dice)}); status != DeathSaveDying {
+		t.Errorf("Expected a modified 20 to be a success, got %s", status)
+	}
+}
+
+// TestDeathSavesDiceSet tests that only the d20 of a save rolled with other dice is the natural roll
+func TestDeathSavesDiceSet(t *testing.T) {
+	blessed := func(d20 int, d4 int) Roll {
+		return rollSet{&singleRoll{value: d20, dice: D20}, &singleRoll{value: d4, dice: D4}}
+	}
+
+	ds := NewDeathSaves()
+	if status := ds.Record(blessed(17, 3)); status != DeathSaveDying || ds.Successes() != 1 {
+		t.Errorf("Expected 17 and a d4 of 3 to be a success, got %s", ds)
+	}
+	if status := ds.Record(blessed(1, 4)); status != DeathSaveDying || ds.Failures() != 2 {
+		t.Errorf("Expected a natural 1 and a d4 to count as two failures, got %s", ds)
+	}
+	if status := ds.Record(blessed(20, 2)); status != DeathSaveRevived {
+		t.Errorf("Expected a natural 20 and a d4 to revive the creature, got %s", status)
+	}
+
+	// A real roll of a d20 and a d4 is only revived on a natural 20
+	for range 2000 {
+		ds = NewDeathSaves()
+		r := NewDiceSet(D20, D4).Roll()
+		if status := ds.Record(r); (status == DeathSaveRevived) != (r.(rollSet)[0].Value() == 20) {
+			t.Fatalf("Unexpected status %s for %s", status, r)
+		}
+	}
+}
+
+// TestDeathSavesDamage tests taking damage while at 0 hit points
+func TestDeathSavesDamage(t *testing.T) {
+	ds := NewDeathSaves(WithMaxHitPoints(20))
+
+	ds.TakeDamage(5, false)
+	if ds.Failures() != 1 {
+		t.Errorf("Expected damage to cause 1 failure, got %d", ds.Failures())
+	}
+	if status := ds.TakeDamage(5, true); status != DeathSaveDead {
+		t.Errorf("Expected a critical hit to cause 2 failures, got %s", ds)
+	}
+
+	// Massive damage causes instant death
+	ds = NewDeathSaves(WithMaxHitPoints(20))
+	if status := ds.TakeDamage(20, false); status != DeathSaveDead {
+		t.Errorf("Expected massive damage to kill the creature, got %s", status)
+	}
+
+	// A stable creature that takes damage starts dying again
+	ds = NewDeathSaves()
+	ds.Stabilize()
+	if status := ds.TakeDamage(3, false); status != DeathSaveDying || ds.Failures() != 1 {
+		t.Errorf("Expected stable creature to be dying with 1 failure, got %s", ds)
+	}
+
+	// A revived creature loses its regained hit points first
+	ds = NewDeathSaves()
+	ds.Heal(5)
+	if status := ds.TakeDamage(3, false); status != DeathSaveRevived || ds.HitPoints() != 2 {
+		t.Errorf("Expected revived creature to have 2 hit points, got %s", ds)
+	}
+	if status := ds.TakeDamage(3, false); status != DeathSaveDying || ds.Failures() != 0 {
+		t.Errorf("Expected creature to be dying with no failures, got %s", ds)
+	}
+}
+
+// TestDeathSavesRoll tests rolling death saving throws
+func TestDeathSavesRoll(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		ds := NewDeathSaves()
+		for ds.Status() == DeathSaveDying {
+			ds.Roll()
+		}
+		if len(ds.Rolls()) == 0 || len(ds.Rolls()) > DeathSavesToStabilize+DeathSavesToDie-1 {
+			t.Errorf("Unexpected number of death saving throws: %s", ds)
+		}
+	}
+}
+
+// TestDeathSavesString tests the string representation of the death saving throws
+func TestDeathSavesString(t *testing.T) {
+	ds := NewDeathSaves()
+	ds.Record(newTestDeathSave(12))
+	ds.Record(newTestDeathSave(7))
+
+	expected := "Dying (1 successes, 1 failures): 12, 7"
+	if ds.String() != expected {
+		t.Errorf("Expected `%s`, got `%s`", expected, ds.String())
+	}
+
+	ds.Heal(4)
+	expected = "Revived (0 successes, 0 failures, 4 HP): 12, 7"
+	if ds.String() != expected {
+		t.Errorf("Expected `%s`, got `%s`", expected, ds.String())
+	}
+}
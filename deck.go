@@ -0,0 +1,324 @@
+package dice
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	ErrDeckEmpty   = errors.New("deck is empty")
+	ErrInvalidDraw = errors.New("invalid number of cards to draw")
+)
+
+// Suit is the suit of a playing card. Suits are ordered from lowest to highest for breaking ties, as
+// is done for initiative in Savage Worlds.
+type Suit int
+
+const (
+	NoSuit   Suit = iota // Jokers and custom cards without a suit
+	Clubs                // The lowest suit
+	Diamonds             // The second lowest suit
+	Hearts               // The second highest suit
+	Spades               // The highest suit
+)
+
+// Rank is the rank of a playing card, with aces high and jokers above aces.
+type Rank int
+
+// Ranks of the face cards, aces and jokers; other cards use their number as their rank
+const (
+	Jack  Rank = 11
+	Queen Rank = 12
+	King  Rank = 13
+	Ace   Rank = 14
+	Joker Rank = 15
+)
+
+// Card is a card drawn from a deck. The value of a card is its rank, so it may be used in a Check
+// against a DifficultyClass, while Compare also uses the suit to break ties, such as when ordering
+// initiative.
+type Card interface {
+	Value                   // The value of the card, which is its rank
+	Name() string           // The name of the card, such as `Ace of Spades`
+	Rank() Rank             // The rank of the card
+	Suit() Suit             // The suit of the card
+	IsJoker() bool          // Returns true if the card is a joker
+	Compare(other Card) int // Compares the rank, then the suit, returning -1, 0 or +1
+	fmt.Stringer            // String representation of the card
+}
+
+// Deck is a deck of cards that are drawn without replacement until they are shuffled back into the deck.
+type Deck interface {
+	Draw() (Card, error)         // Draws the top card
+	DrawN(n int) ([]Card, error) // Draws cards from the top of the deck
+	Peek() (Card, error)         // The top card, without drawing it
+	Discard(cards ...Card)       // Puts drawn cards in the discard pile
+	Shuffle()                    // Shuffles the cards that have not been drawn
+	Reshuffle()                  // Shuffles the discard pile back into the deck
+	Remaining() int              // The number of cards that have not been drawn
+	Discards() []Card            // The cards in the discard pile
+	NeedsReshuffle() bool        // Returns true if a joker was drawn since the last reshuffle
+	fmt.Stringer                 // String representation of the deck
+}
+
+// card is an implementation of the Card interface.
+type card struct {
+	name string // The name of the card
+	rank Rank   // The rank of the card
+	suit Suit   // The suit of the card
+}
+
+// deck is an implementation of the Deck interface.
+type deck struct {
+	cards            []Card // The cards that have not been drawn, with the top card last
+	discards         []Card // The discard pile
+	reshuffleOnJoker bool   // If true, the discard pile is shuffled back in when a joker is drawn
+	jokerDrawn       bool   // If true, a joker was drawn since the last reshuffle
+}
+
+// DeckOption is a function that can modify the default values of a deck.
+type DeckOption func(*deck)
+
+// NewCard creates a card with the name, rank and suit, for use in a custom deck such as a tarot deck.
+func NewCard(name string, rank Rank, suit Suit) Card {
+	return &card{
+		name: name,
+		rank: rank,
+		suit: suit,
+	}
+}
+
+// NewDeck creates a shuffled deck with the cards.
+func NewDeck(cards []Card, opts ...DeckOption) Deck {
+	d := &deck{
+		cards: make([]Card, 0, len(cards)),
+	}
+	d.cards = append(d.cards, cards...)
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	d.Shuffle()
+	return d
+}
+
+// NewStandardDeck creates a shuffled deck of 52 playing cards.
+func NewStandardDeck(opts ...DeckOption) Deck {
+	return NewDeck(standardCards(), opts...)
+}
+
+// NewDeckWithJokers creates a shuffled deck of 52 playing cards and two jokers.
+func NewDeckWithJokers(opts ...DeckOption) Deck {
+	cards := standardCards()
+	cards = append(cards, NewCard("Red Joker", Joker, NoSuit), NewCard("Black Joker", Joker, NoSuit))
+	return NewDeck(cards, opts...)
+}
+
+// WithReshuffleOnJoker shuffles the discard pile back into the deck after a joker has been drawn and
+// discarded, as is done at the end of a round of initiative in Savage Worlds. The reshuffle happens on
+// the next draw, so the rest of the round is dealt from the deck as it was.
+func WithReshuffleOnJoker() DeckOption {
+	return func(d *deck) {
+		d.reshuffleOnJoker = true
+	}
+}
+
+// standardCards returns the 52 cards in a standard deck of playing cards.
+func standardCards() []Card {
+	cards := make([]Card, 0, 54)
+	for _, suit := range []Suit{Clubs, Diamonds, Hearts, Spades} {
+		for rank := Rank(2); rank <= Ace; rank++ {
+			cards = append(cards, NewCard(rank.String()+" of "+suit.String(), rank, suit))
+		}
+	}
+	return cards
+}
+
+// Draw draws the top card. If there are no cards left to draw, or if the deck reshuffles on a joker
+// and a drawn joker has been discarded, the discard pile is shuffled back into the deck first. An error
+// is returned if there are no cards in either.
+func (d *deck) Draw() (Card, error) {
+	if len(d.cards) == 0 || d.reshuffleOnJoker && d.jokerDrawn && containsJoker(d.discards) {
+		d.Reshuffle()
+	}
+	if len(d.cards) == 0 {
+		return nil, ErrDeckEmpty
+	}
+
+	top := d.cards[len(d.cards)-1]
+	d.cards = d.cards[:len(d.cards)-1]
+	if top.IsJoker() {
+		d.jokerDrawn = true
+	}
+	return top, nil
+}
+
+// containsJoker returns true if any of the cards is a joker.
+func containsJoker(cards []Card) bool {
+	for _, c := range cards {
+		if c.IsJoker() {
+			return true
+		}
+	}
+	return false
+}
+
+// DrawN draws n cards from the top of the deck. If n is negative or there are not enough cards, no
+// cards are drawn and an error is returned.
+func (d *deck) DrawN(n int) ([]Card, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("%w: cannot draw %d cards", ErrInvalidDraw, n)
+	}
+	if n > len(d.cards)+len(d.discards) {
+		return nil, fmt.Errorf("%w: cannot draw %d cards", ErrDeckEmpty, n)
+	}
+
+	cards := make([]Card, 0, n)
+	for range n {
+		c, err := d.Draw()
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+	return cards, nil
+}
+
+// Peek returns the top card without drawing it. An error is returned if there are no cards left to draw.
+func (d *deck) Peek() (Card, error) {
+	if len(d.cards) == 0 {
+		return nil, ErrDeckEmpty
+	}
+	return d.cards[len(d.cards)-1], nil
+}
+
+// Discard puts drawn cards in the discard pile.
+func (d *deck) Discard(cards ...Card) {
+	d.discards = append(d.discards, cards...)
+}
+
+// Shuffle shuffles the cards that have not been drawn.
+func (d *deck) Shuffle() {
+	rng.Shuffle(len(d.cards), func(i, j int) {
+		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+	})
+}
+
+// Reshuffle shuffles the discard pile back into the deck. Cards that have been drawn but not discarded
+// are not returned to the deck.
+func (d *deck) Reshuffle() {
+	d.cards = append(d.cards, d.discards...)
+	d.discards = nil
+	d.jokerDrawn = false
+	d.Shuffle()
+}
+
+// Remaining returns the number of cards that have not been drawn.
+func (d *deck) Remaining() int {
+	return len(d.cards)
+}
+
+// Discards returns the cards in the discard pile.
+func (d *deck) Discards() []Card {
+	return d.discards
+}
+
+// NeedsReshuffle returns true if a joker was drawn since the last reshuffle.
+func (d *deck) NeedsReshuffle() bool {
+	return d.jokerDrawn
+}
+
+// String returns a string representation of the deck, such as `Deck: 40 cards, 12 discarded`.
+func (d *deck) String() string {
+	return "Deck: " + strconv.Itoa(len(d.cards)) + " cards, " + strconv.Itoa(len(d.discards)) + " discarded"
+}
+
+// Name returns the name of the card.
+func (c *card) Name() string {
+	return c.name
+}
+
+// Rank returns the rank of the card.
+func (c *card) Rank() Rank {
+	return c.rank
+}
+
+// Suit returns the suit of the card.
+func (c *card) Suit() Suit {
+	return c.suit
+}
+
+// IsJoker returns true if the card is a joker.
+func (c *card) IsJoker() bool {
+	return c.rank == Joker
+}
+
+// Value returns the value of the card, which is its rank.
+func (c *card) Value() int {
+	return int(c.rank)
+}
+
+// Check returns true if the value of the card is equal to or greater than the value.
+func (c *card) Check(v Value) bool {
+	return c.Value() >= v.Value()
+}
+
+// IsCriticalHit returns false, as cards do not have critical hits.
+func (c *card) IsCriticalHit() bool {
+	return false
+}
+
+// IsCriticalMiss returns false, as cards do not have critical misses.
+func (c *card) IsCriticalMiss() bool {
+	return false
+}
+
+// Compare compares the card to the other card by rank and then by suit, returning -1 if the card is
+// lower, 0 if they are equal, and +1 if the card is higher.
+func (c *card) Compare(other Card) int {
+	if c.rank != other.Rank() {
+		return cmp.Compare(c.rank, other.Rank())
+	}
+	return cmp.Compare(c.suit, other.Suit())
+}
+
+// String returns the name of the card.
+func (c *card) String() string {
+	return c.name
+}
+
+// String returns the name of the rank, such as `Ace` or `10`.
+func (r Rank) String() string {
+	switch r {
+	case Jack:
+		return "Jack"
+	case Queen:
+		return "Queen"
+	case King:
+		return "King"
+	case Ace:
+		return "Ace"
+	case Joker:
+		return "Joker"
+	default:
+		return strconv.Itoa(int(r))
+	}
+}
+
+// String returns the name of the suit, such as `Spades`.
+func (s Suit) String() string {
+	switch s {
+	case Clubs:
+		return "Clubs"
+	case Diamonds:
+		return "Diamonds"
+	case Hearts:
+		return "Hearts"
+	case Spades:
+		return "Spades"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,157 @@
+package dice
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// TestStandardDecks tests the cards in the standard decks
+func TestStandardDecks(t *testing.T) {
+	d := NewStandardDeck()
+	if d.Remaining() != 52 {
+		t.Fatalf("Expected 52 cards, got %d", d.Remaining())
+	}
+	seen := make(map[string]bool)
+	for range 52 {
+		c, err := d.Draw()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if seen[c.Name()] || c.IsJoker() || c.Suit() == NoSuit || c.Rank() < 2 || c.Rank() > Ace {
+			t.Errorf("Unexpected card %s", c)
+		}
+		seen[c.Name()] = true
+	}
+	if _, err := d.Draw(); !errors.Is(err, ErrDeckEmpty) {
+		t.Errorf("Expected %v, got %v", ErrDeckEmpty, err)
+	}
+	if _, err := d.Peek(); !errors.Is(err, ErrDeckEmpty) {
+		t.Errorf("Expected %v, got %v", ErrDeckEmpty, err)
+	}
+
+	cards, err := NewDeckWithJokers().DrawN(54)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	jokers := 0
+	for _, c := range cards {
+		if c.IsJoker() {
+			jokers++
+		}
+	}
+	if jokers != 2 {
+		t.Errorf("Expected 2 jokers, got %d", jokers)
+	}
+}
+
+// TestDeckDrawAndDiscard tests drawing, peeking, discarding and reshuffling
+func TestDeckDrawAndDiscard(t *testing.T) {
+	d := NewStandardDeck()
+	top, err := d.Peek()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	drawn, err := d.Draw()
+	if err != nil || drawn != top {
+		t.Errorf("Expected to draw the peeked card %s, got %s", top, drawn)
+	}
+
+	hand, err := d.DrawN(5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	d.Discard(hand...)
+	if d.Remaining() != 46 || len(d.Discards()) != 5 {
+		t.Errorf("Unexpected deck %s", d)
+	}
+	if d.String() != "Deck: 46 cards, 5 discarded" {
+		t.Errorf("Unexpected string `%s`", d)
+	}
+
+	// The discard pile is shuffled back in, but the card that was not discarded is not
+	d.Reshuffle()
+	if d.Remaining() != 51 || len(d.Discards()) != 0 {
+		t.Errorf("Unexpected deck after reshuffling %s", d)
+	}
+
+	// An empty deck is refilled from the discard pile
+	rest, _ := d.DrawN(51)
+	d.Discard(rest[:3]...)
+	if _, err := d.DrawN(4); !errors.Is(err, ErrDeckEmpty) {
+		t.Errorf("Expected %v, got %v", ErrDeckEmpty, err)
+	}
+	if _, err := d.Draw(); err != nil || d.Remaining() != 2 {
+		t.Errorf("Expected the discard pile to be reshuffled, got %v and %s", err, d)
+	}
+
+	// A negative number of cards cannot be drawn, and drawing no cards leaves the deck unchanged
+	if _, err := d.DrawN(-1); !errors.Is(err, ErrInvalidDraw) {
+		t.Errorf("Expected %v, got %v", ErrInvalidDraw, err)
+	}
+	if cards, err := d.DrawN(0); err != nil || len(cards) != 0 || d.Remaining() != 2 {
+		t.Errorf("Expected no cards to be drawn, got %v and %v", cards, err)
+	}
+}
+
+// TestDeckReshuffleOnJoker tests reshuffling after a joker is drawn and discarded
+func TestDeckReshuffleOnJoker(t *testing.T) {
+	joker := NewCard("Joker", Joker, NoSuit)
+	cards := []Card{joker, NewCard("2 of Clubs", 2, Clubs), NewCard("3 of Clubs", 3, Clubs), NewCard("4 of Clubs", 4, Clubs)}
+	d := NewDeck(cards, WithReshuffleOnJoker())
+
+	var round []Card
+	for !d.NeedsReshuffle() {
+		c, err := d.Draw()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		round = append(round, c)
+	}
+
+	// Drawing without discarding the joker does not reshuffle
+	remaining := d.Remaining()
+	if remaining > 0 {
+		c, _ := d.Draw()
+		round = append(round, c)
+		if d.Remaining() != remaining-1 {
+			t.Errorf("Expected %d cards, got %d", remaining-1, d.Remaining())
+		}
+	}
+
+	// Once the joker is discarded, the next draw reshuffles every discarded card
+	d.Discard(round...)
+	if _, err := d.Draw(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d.Remaining() != 3 || len(d.Discards()) != 0 {
+		t.Errorf("Expected the deck to be reshuffled, got %s", d)
+	}
+}
+
+// TestCardValue tests comparing cards and using them as values
+func TestCardValue(t *testing.T) {
+	aceOfSpades := NewCard("Ace of Spades", Ace, Spades)
+	aceOfHearts := NewCard("Ace of Hearts", Ace, Hearts)
+	tenOfClubs := NewCard("10 of Clubs", 10, Clubs)
+	joker := NewCard("Red Joker", Joker, NoSuit)
+
+	if aceOfSpades.Value() != 14 || !aceOfSpades.Check(NewDifficultyClass(14)) || tenOfClubs.Check(NewDifficultyClass(11)) {
+		t.Errorf("Unexpected values %d and %d", aceOfSpades.Value(), tenOfClubs.Value())
+	}
+	if aceOfSpades.Compare(aceOfHearts) != 1 || aceOfHearts.Compare(aceOfSpades) != -1 || aceOfHearts.Compare(aceOfHearts) != 0 {
+		t.Errorf("Expected spades to beat hearts")
+	}
+
+	// Initiative is dealt from the highest card to the lowest
+	order := []Card{tenOfClubs, aceOfHearts, joker, aceOfSpades}
+	slices.SortFunc(order, func(a, b Card) int {
+		return b.Compare(a)
+	})
+	if order[0] != joker || order[1] != aceOfSpades || order[2] != aceOfHearts || order[3] != tenOfClubs {
+		t.Errorf("Unexpected order %v", order)
+	}
+	if aceOfSpades.String() != "Ace of Spades" || Rank(7).String() != "7" || Diamonds.String() != "Diamonds" {
+		t.Errorf("Unexpected strings")
+	}
+}
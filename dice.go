@@ -13,6 +13,14 @@ import (
 // rng is a random number generator used for dice rolls
 var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
 
+// Seed seeds the random number generator used for dice rolls, so that the same rolls are made each time
+// the same sequence of rolls is made after seeding, such as for tests and replays. The generator is
+// global, so seeding it changes the rolls of every other caller, and it is not safe for concurrent use.
+// Shuffle bags are emptied and refilled from the new generator the next time a face is drawn.
+func Seed(seed int64) {
+	rng = rand.New(rand.NewSource(seed))
+}
+
 // Pre-defined dice types
 var (
 	D4 = &dice{
@@ -72,6 +80,7 @@ type Dice interface {
 	NumSides() int           // The number of sides on the dice
 	Modifier() int           // The constant modifier to add to the roll
 	Source() string          // Get the source for the dice
+	DamageType() DamageType  // Get the type of damage dealt by the dice
 	Roll(...RollOption) Roll // Rolls a dice and returns the result
 	fmt.Stringer             // String representation of the dice
 	Str() string             // Returns a string representation of the dice, but without the leading '-'
@@ -92,13 +101,15 @@ type Roll interface {
 
 // dice is an implementation of the Dice interface.
 type dice struct {
-	numDice  int    // The number of dice to roll
-	numSides int    // The number of sides on the dice
-	modifier int    // A constant value to add to the roll
-	roll     Roll   // The roll of the dice
-	source   string // Source for the dice; used in creating the descripton output
-	isLucky  bool   // If true, the dice is a lucky dice that is re-rolled if it rolls a 1
-	isDebuff bool   // The dice roll is negated
+	numDice    int         // The number of dice to roll
+	numSides   int         // The number of sides on the dice
+	modifier   int         // A constant value to add to the roll
+	roll       Roll        // The roll of the dice
+	source     string      // Source for the dice; used in creating the descripton output
+	damageType DamageType  // The type of damage dealt by the dice
+	isLucky    bool        // If true, the dice is a lucky dice that is re-rolled if it rolls a 1
+	isDebuff   bool        // The dice roll is negated
+	shuffleBag *shuffleBag // If set, faces are drawn from a shuffle bag instead of being rolled
 }
 
 // DiceOption is a function that modifies the default values of a dice.
@@ -106,23 +117,26 @@ type DiceOption func(*dice)
 
 // roll is an implementation of the Roll interface
 type roll struct {
-	rollType           RollType      // Type of roll (ROLL_ONCE, ROLL_ADVANTAGE, ROLL_DISADVANTATE)
-	rolls              []*singleRoll // The values rolled for the dice, if rolled with advantage or disadvantage
-	value              int           // The value of the roll
-	criticalHitAllowed bool          // If true, the dice allows for a critical hit
-	criticalHit        int           // The value for a critical hit; defaults to 20
-	criticalMiss       int           // The value for a critical miss; defaults to 1
-	dice               *dice         // The dice used for the roll
+	rollType           RollType           // Type of roll (ROLL_ONCE, ROLL_ADVANTAGE, ROLL_DISADVANTATE)
+	rolls              []*singleRoll      // The values rolled for the dice, if rolled with advantage or disadvantage
+	value              int                // The value of the roll
+	criticalHitAllowed bool               // If true, the dice allows for a critical hit
+	criticalHit        int                // The value for a critical hit; defaults to 20
+	criticalMiss       int                // The value for a critical miss; defaults to 1
+	criticalDamage     CriticalDamageRule // The rule used to apply critical damage, if any
+	random             *rand.Rand         // The random number generator for the roll; the global one if nil
+	dice               *dice              // The dice used for the roll
 }
 
 // singleRoll represents a single roll of the dice. Whenn rolling a dice, there may be one roll or,
 // if rolling with advantage or disadvantage, two rolls.
 type singleRoll struct {
-	value              int   // The value of the roll
-	criticalHitAllowed bool  // If true, the roll allows for a critical hit
-	criticalHit        int   // The value for a critical hit; defaults to 20
-	criticalMiss       int   // The value for a critical miss; defaults to 1
-	dice               *dice // The dice used for the roll
+	value              int                // The value of the roll
+	criticalHitAllowed bool               // If true, the roll allows for a critical hit
+	criticalHit        int                // The value for a critical hit; defaults to 20
+	criticalMiss       int                // The value for a critical miss; defaults to 1
+	criticalDamage     CriticalDamageRule // The rule used to apply critical damage, if any
+	dice               *dice              // The dice used for the roll
 }
 
 // RollOption is a function that can modify the default values of a roll.
@@ -151,7 +165,8 @@ func NewConstant(value int, opts ...DiceOption) Dice {
 }
 
 // ParseDice parses a string representation of a dice into a Dice. Some supported formats are:
-// `1d20` `1d20+5`, `1d8-2`, and `d4`.
+// `1d20` `1d20+5`, `1d8-2`, and `d4`. Notation such as `d66` is a single die with 66 sides; digit
+// dice are parsed with ParseDigitDice.
 func ParseDice(str string, opts ...DiceOption) Dice {
 	str = strings.TrimSpace(str)
 	str = strings.ToLower(str)
@@ -210,11 +225,15 @@ func ParseDice(str string, opts ...DiceOption) Dice {
 // creating a new dice based on an existing one, but with different options applied.
 func (d *dice) Customize(opts ...DiceOption) Dice {
 	newDice := &dice{
-		numDice:  d.numDice,
-		numSides: d.numSides,
-		modifier: d.modifier,
-		isLucky:  d.isLucky,
-		isDebuff: d.isDebuff,
+		numDice:    d.numDice,
+		numSides:   d.numSides,
+		modifier:   d.modifier,
+		isLucky:    d.isLucky,
+		isDebuff:   d.isDebuff,
+		damageType: d.damageType,
+	}
+	if d.shuffleBag != nil {
+		newDice.shuffleBag = newShuffleBag(d.shuffleBag.copies)
 	}
 
 	for _, opt := range opts {
@@ -279,19 +298,51 @@ func (d *dice) Roll(opts ...RollOption) Roll {
 
 	switch r.rollType {
 	case RollWithAdvantage:
-		r.rolls = []*singleRoll{d.rollDice(r.criticalHitAllowed, r.criticalHit, r.criticalMiss), d.rollDice(r.criticalHitAllowed, r.criticalHit, r.criticalMiss)}
+		r.rolls = []*singleRoll{d.rollDice(r), d.rollDice(r)}
 		r.value = max(r.rolls[0].Value(), r.rolls[1].Value())
 	case RollWithDisadvantage:
-		r.rolls = []*singleRoll{d.rollDice(r.criticalHitAllowed, r.criticalHit, r.criticalMiss), d.rollDice(r.criticalHitAllowed, r.criticalHit, r.criticalMiss)}
+		r.rolls = []*singleRoll{d.rollDice(r), d.rollDice(r)}
 		r.value = min(r.rolls[0].Value(), r.rolls[1].Value())
 	default:
-		r.rolls = []*singleRoll{d.rollDice(r.criticalHitAllowed, r.criticalHit, r.criticalMiss)}
+		r.rolls = []*singleRoll{d.rollDice(r)}
 		r.value = r.rolls[0].Value()
 	}
 
 	return r
 }
 
+// WithRandomSource rolls the dice using the random number generator instead of the global one, so that
+// the rolls can be repeated with a generator seeded the same way without seeding the global generator.
+// Every dice in a dice set and every sub-table of a table is rolled with the same generator. The
+// generator is not safe for concurrent use, so it should not be shared with other goroutines.
+func WithRandomSource(random *rand.Rand) RollOption {
+	return func(r *roll) {
+		r.random = random
+	}
+}
+
+// randomSource returns the random number generator for the roll, which is the global one unless
+// another was provided with WithRandomSource.
+func (r *roll) randomSource() *rand.Rand {
+	if r.random != nil {
+		return r.random
+	}
+	return rng
+}
+
+// randomOptions returns the options that roll further dice with the same random number generator as
+// the options, without any of their other effects, such as advantage.
+func randomOptions(opts []RollOption) []RollOption {
+	r := &roll{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.random == nil {
+		return nil
+	}
+	return []RollOption{WithRandomSource(r.random)}
+}
+
 // ReRoll re-rolls the dice with the provided options, returning the new Roll.
 func (r *roll) ReRoll(opts ...RollOption) Roll {
 	return r.dice.Roll(opts...)
@@ -328,18 +379,38 @@ func (d *dice) Source() string {
 	return d.source
 }
 
+// DamageType returns the type of damage dealt by the dice.
+func (d *dice) DamageType() DamageType {
+	return d.damageType
+}
+
 // rollDice rolls the dice and returns the value. If the dice is lucky, it will re-roll if it rolls a 1.
-func (d *dice) rollDice(criticalHitAllowed bool, criticalHit int, criticalMiss int) *singleRoll {
+// If the roll uses a critical damage rule, the rule is applied to the value.
+func (d *dice) rollDice(r *roll) *singleRoll {
+	numDice := d.numDice
+	if r.criticalDamage == CriticalDoubleDice {
+		numDice *= 2
+	}
+
 	value := d.modifier
-	for range d.numDice {
-		rollValue := rng.Intn(d.numSides) + 1 // rng.Intn returns a value in the range [0, n), so we add 1 to get [1, n]
+	for range numDice {
+		rollValue := d.rollFace(r.randomSource())
 		if rollValue == 1 && d.isLucky {
 			// If the dice is lucky, re-roll if it rolls a 1
-			rollValue = rng.Intn(d.numSides) + 1
+			rollValue = d.rollFace(r.randomSource())
 		}
 		value += rollValue
 	}
 
+	switch r.criticalDamage {
+	case CriticalMaxPlusRoll:
+		value += d.numDice * d.numSides
+	case CriticalTimesTwo:
+		value *= 2
+	case CriticalTimesThree:
+		value *= 3
+	}
+
 	// If this is a debuff dice, negate the value
 	if d.isDebuff {
 		value = -value
@@ -348,9 +419,10 @@ func (d *dice) rollDice(criticalHitAllowed bool, criticalHit int, criticalMiss i
 	roll := &singleRoll{
 		value:              value,
 		dice:               d,
-		criticalHitAllowed: criticalHitAllowed,
-		criticalHit:        criticalHit,
-		criticalMiss:       criticalMiss,
+		criticalHitAllowed: r.criticalHitAllowed,
+		criticalHit:        r.criticalHit,
+		criticalMiss:       r.criticalMiss,
+		criticalDamage:     r.criticalDamage,
 	}
 
 	return roll
@@ -593,6 +665,10 @@ func (d *dice) String() string {
 func (d *dice) Str() string {
 	var sb strings.Builder
 	sb.WriteString(getDiceString(d.numDice, d.numSides, d.modifier))
+	if d.damageType != "" {
+		sb.WriteString(" ")
+		sb.WriteString(string(d.damageType))
+	}
 
 	if d.source != "" {
 		sb.WriteString(" (")
@@ -633,6 +709,10 @@ func (r *roll) Str() string {
 	case !r.dice.IsConstant():
 		sb.WriteString(" (")
 		sb.WriteString(getDiceString(r.dice.numDice, r.dice.numSides, r.dice.modifier))
+		if r.dice.damageType != "" {
+			sb.WriteString(" ")
+			sb.WriteString(string(r.dice.damageType))
+		}
 		if r.dice.Source() != "" {
 			sb.WriteString(", ")
 			sb.WriteString(r.dice.Source())
@@ -643,12 +723,23 @@ func (r *roll) Str() string {
 		case r.RolledWithDisadvantage():
 			sb.WriteString(", Disadvantage")
 		}
+		if r.dice.shuffleBag != nil {
+			sb.WriteString(", Shuffle Bag")
+		}
 		sb.WriteString(")")
-	case r.dice.Source() != "":
+	case r.dice.Source() != "" || r.dice.damageType != "":
 		sb.WriteString(" (")
+		sb.WriteString(string(r.dice.damageType))
+		if r.dice.Source() != "" && r.dice.damageType != "" {
+			sb.WriteString(", ")
+		}
 		sb.WriteString(r.dice.Source())
 		sb.WriteString(")")
 	}
+	if r.isCriticalDamage() && !r.IsCriticalHit() {
+		// A critical hit already shows that the roll is critical
+		sb.WriteString(" (Critical!)")
+	}
 
 	return sb.String()
 }
@@ -683,6 +774,10 @@ func (r *singleRoll) Str() string {
 	case !r.dice.IsConstant():
 		sb.WriteString(" (")
 		sb.WriteString(getDiceString(r.dice.numDice, r.dice.numSides, r.dice.modifier))
+		if r.dice.damageType != "" {
+			sb.WriteString(" ")
+			sb.WriteString(string(r.dice.damageType))
+		}
 		if r.dice.Source() != "" {
 			sb.WriteString(", ")
 			sb.WriteString(r.dice.Source())
@@ -693,12 +788,23 @@ func (r *singleRoll) Str() string {
 		case r.RolledWithDisadvantage():
 			sb.WriteString(", Disadvantage")
 		}
+		if r.dice.shuffleBag != nil {
+			sb.WriteString(", Shuffle Bag")
+		}
 		sb.WriteString(")")
-	case r.dice.Source() != "":
+	case r.dice.Source() != "" || r.dice.damageType != "":
 		sb.WriteString(" (")
+		sb.WriteString(string(r.dice.damageType))
+		if r.dice.Source() != "" && r.dice.damageType != "" {
+			sb.WriteString(", ")
+		}
 		sb.WriteString(r.dice.Source())
 		sb.WriteString(")")
 	}
+	if r.isCriticalDamage() && !r.IsCriticalHit() {
+		// A critical hit already shows that the roll is critical
+		sb.WriteString(" (Critical!)")
+	}
 
 	return sb.String()
 }
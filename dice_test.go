@@ -535,3 +535,30 @@ func TestStrMethods(t *testing.T) {
 		t.Errorf("roll.Str() with critical hit allowed returned empty string")
 	}
 }
+
+// TestSeed tests that seeding the random number generator repeats the same rolls
+func TestSeed(t *testing.T) {
+	d := NewDice(4, 20)
+	Seed(42)
+	first := make([]string, 0, 10)
+	for range 10 {
+		first = append(first, d.Roll().String())
+	}
+	Seed(42)
+	for i := range 10 {
+		if r := d.Roll().String(); r != first[i] {
+			t.Errorf("Expected roll %d to be %s, got %s", i, first[i], r)
+		}
+	}
+
+	// Shuffle bags are refilled after seeding, so a partly drawn bag does not change the rolls
+	bag := ParseDice("1d6", WithShuffleBag(1))
+	Seed(42)
+	drawn := []int{bag.Roll().Value(), bag.Roll().Value(), bag.Roll().Value()}
+	Seed(42)
+	for i := range drawn {
+		if value := bag.Roll().Value(); value != drawn[i] {
+			t.Errorf("Expected draw %d to be %d, got %d", i, drawn[i], value)
+		}
+	}
+}
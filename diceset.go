@@ -76,9 +76,36 @@ func (ds diceSet) Source() string {
 	return ds[0].Source()
 }
 
+// DamageType returns the type of damage dealt by the dice set. This is the damage type of the dice
+// if they all deal the same type of damage, or an empty damage type otherwise.
+func (ds diceSet) DamageType() DamageType {
+	if len(ds) == 0 {
+		return ""
+	}
+	damageType := ds[0].DamageType()
+	for _, d := range ds[1:] {
+		if d.DamageType() != damageType {
+			return ""
+		}
+	}
+	return damageType
+}
+
 // Roll rolls the dice set and returns the result. The options are applied only to the first dice that
-// is rolled, and can be used to roll with advantage or disadvantage.
+// is rolled, and can be used to roll with advantage or disadvantage. A critical damage rule and the
+// random number generator are applied to every dice in the set.
 func (ds diceSet) Roll(opts ...RollOption) Roll {
+	r := &roll{}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	// Critical damage and the random number generator apply to all of the dice
+	otherOpts := randomOptions(opts)
+	if r.criticalDamage != 0 {
+		otherOpts = append(otherOpts, WithCriticalDamage(r.criticalDamage))
+	}
+
 	rollSet := newRollSet(ds)
 	for i, d := range ds {
 		var roll Roll
@@ -86,8 +113,7 @@ func (ds diceSet) Roll(opts ...RollOption) Roll {
 			// If this is the first dice, then we roll it with the options applied
 			roll = d.Roll(opts...)
 		} else {
-			// Otherwise, roll the dice with no options applied
-			roll = d.Roll()
+			roll = d.Roll(otherOpts...)
 		}
 		rollSet = append(rollSet, roll)
 	}
@@ -138,12 +164,18 @@ func (rs rollSet) Check(v Value) bool {
 // IsCriticalHit checks if the roll is a critical success.
 // This is true iff the first roll in the set is a critical success.
 func (rs rollSet) IsCriticalHit() bool {
+	if len(rs) == 0 {
+		return false
+	}
 	return rs[0].IsCriticalHit()
 }
 
 // IsCriticalMiss checks if the roll is a critical failure.
 // This is true iff the first roll in the set is a critical failure.
 func (rs rollSet) IsCriticalMiss() bool {
+	if len(rs) == 0 {
+		return false
+	}
 	return rs[0].IsCriticalMiss()
 }
 
@@ -159,11 +191,17 @@ func (rs rollSet) GetAllRolls() []Roll {
 
 // RolledWithDisadvantage checks if the roll was made with disadvantage.
 func (rs rollSet) RolledWithDisadvantage() bool {
+	if len(rs) == 0 {
+		return false
+	}
 	return rs[0].RolledWithDisadvantage()
 }
 
 // RolledWithAdvantage checks if the roll was made with advantage.
 func (rs rollSet) RolledWithAdvantage() bool {
+	if len(rs) == 0 {
+		return false
+	}
 	return rs[0].RolledWithAdvantage()
 }
 
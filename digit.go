@@ -0,0 +1,356 @@
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidDigitDice = errors.New("invalid digit dice")
+
+// Pre-defined digit dice
+var (
+	D66 = &digitDice{
+		sides: []int{6, 6},
+	}
+	D666 = &digitDice{
+		sides: []int{6, 6, 6},
+	}
+)
+
+// DigitRoll is a roll of digit dice, where each die is read as a digit of the value.
+type DigitRoll interface {
+	Roll
+	Digits() []int // The value rolled on each die, from the first digit to the last
+}
+
+// digitDice is an implementation of the Dice interface for dice that are read as digits, such as a d66
+// where one d6 is the tens digit and the other is the units digit.
+type digitDice struct {
+	sides  []int  // The number of sides on each die, from the first digit to the last
+	source string // Source for the dice; used in creating the description output
+	roll   Roll   // The last roll of the dice
+}
+
+// digitRoll is an implementation of the DigitRoll interface.
+type digitRoll struct {
+	dice     *digitDice   // The dice used for the roll
+	rollType RollType     // Type of roll (ROLL_ONCE, ROLL_ADVANTAGE, ROLL_DISADVANTATE)
+	digits   []int        // The value rolled on each die for the roll that is kept
+	value    int          // The value of the roll
+	rolls    []*digitRoll // The individual rolls, if rolled with advantage or disadvantage
+}
+
+// NewDigitDice returns dice that are read as digits, such as a d66 or d36, with the number of sides on
+// each die from the first digit to the last. Each die must have between 2 and 9 sides, so that it can
+// be read as a single digit. Of the dice options, only WithSource can be used, and an error is returned
+// for any other option.
+func NewDigitDice(sides []int, opts ...DiceOption) (Dice, error) {
+	if len(sides) < 2 {
+		return nil, fmt.Errorf("%w: at least two dice are needed, got %d", ErrInvalidDigitDice, len(sides))
+	}
+	for _, s := range sides {
+		if s < 2 || s > 9 {
+			return nil, fmt.Errorf("%w: each die must have between 2 and 9 sides, got %d", ErrInvalidDigitDice, s)
+		}
+	}
+
+	// Apply the options to a regular dice to find the source
+	d := &dice{}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.numDice != 0 || d.numSides != 0 || d.modifier != 0 || d.damageType != "" || d.isLucky || d.isDebuff || d.shuffleBag != nil {
+		return nil, fmt.Errorf("%w: only WithSource can be used with digit dice", ErrInvalidDigitDice)
+	}
+
+	dd := &digitDice{
+		sides:  make([]int, 0, len(sides)),
+		source: d.source,
+	}
+	dd.sides = append(dd.sides, sides...)
+	return dd, nil
+}
+
+// ParseDigitDice parses a string representation of digit dice, such as `d66`, `d666` or `d36`, where
+// each digit is the number of sides on one die.
+func ParseDigitDice(str string, opts ...DiceOption) (Dice, error) {
+	str = strings.ToLower(strings.TrimSpace(str))
+	digits, found := strings.CutPrefix(str, "d")
+	if !found || digits == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDigitDice, str)
+	}
+
+	sides := make([]int, 0, len(digits))
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidDigitDice, str)
+		}
+		sides = append(sides, int(c-'0'))
+	}
+	return NewDigitDice(sides, opts...)
+}
+
+// IsConstant returns false, as digit dice are never constant.
+func (dd *digitDice) IsConstant() bool {
+	return false
+}
+
+// IsDebuff returns false, as digit dice are never a debuff.
+func (dd *digitDice) IsDebuff() bool {
+	return false
+}
+
+// IsLucky returns false, as digit dice are never lucky.
+func (dd *digitDice) IsLucky() bool {
+	return false
+}
+
+// NumDice returns the number of dice rolled, which is the number of digits.
+func (dd *digitDice) NumDice() int {
+	return len(dd.sides)
+}
+
+// NumSides returns the number of different values that can be rolled, such as 36 for a d66.
+func (dd *digitDice) NumSides() int {
+	return digitOutcomes(dd.sides)
+}
+
+// Modifier returns 0, as digit dice do not have a modifier.
+func (dd *digitDice) Modifier() int {
+	return 0
+}
+
+// Source returns the source of the dice.
+func (dd *digitDice) Source() string {
+	return dd.source
+}
+
+// DamageType returns an empty damage type, as digit dice are not used for damage.
+func (dd *digitDice) DamageType() DamageType {
+	return ""
+}
+
+// GetDice returns a slice with the digit dice as its only element.
+func (dd *digitDice) GetDice() []Dice {
+	return []Dice{dd}
+}
+
+// GetRoll returns the last roll of the dice.
+func (dd *digitDice) GetRoll() Roll {
+	return dd.roll
+}
+
+// Roll rolls each die and reads the results as the digits of the value. If rolled with advantage or
+// disadvantage, the dice are rolled twice and the higher or lower value is used. Other roll options,
+// such as critical hits, do not apply to digit dice.
+func (dd *digitDice) Roll(opts ...RollOption) Roll {
+	r := &roll{
+		rollType: RollOnce,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	dr := &digitRoll{
+		dice:     dd,
+		rollType: r.rollType,
+	}
+	switch r.rollType {
+	case RollWithAdvantage, RollWithDisadvantage:
+		dr.rolls = []*digitRoll{dd.rollDigits(r.randomSource()), dd.rollDigits(r.randomSource())}
+		kept := dr.rolls[0]
+		if (r.rollType == RollWithAdvantage) == (dr.rolls[1].value > kept.value) {
+			kept = dr.rolls[1]
+		}
+		dr.digits, dr.value = kept.digits, kept.value
+	default:
+		single := dd.rollDigits(r.randomSource())
+		dr.digits, dr.value = single.digits, single.value
+	}
+
+	dd.roll = dr
+	return dr
+}
+
+// rollDigits rolls each die once using the random number generator, returning the digits and the value
+// they are read as.
+func (dd *digitDice) rollDigits(random *rand.Rand) *digitRoll {
+	dr := &digitRoll{
+		dice:     dd,
+		rollType: RollOnce,
+		digits:   make([]int, 0, len(dd.sides)),
+	}
+	for _, s := range dd.sides {
+		digit := random.Intn(s) + 1
+		dr.digits = append(dr.digits, digit)
+		dr.value = dr.value*10 + digit
+	}
+	return dr
+}
+
+// digitOutcomes returns the number of different values that can be rolled on digit dice with the sides.
+func digitOutcomes(sides []int) int {
+	outcomes := 1
+	for _, s := range sides {
+		outcomes *= s
+	}
+	return outcomes
+}
+
+// values returns every value that can be rolled, from lowest to highest. Each value is equally likely.
+func (dd *digitDice) values() []int {
+	values := []int{0}
+	for _, s := range dd.sides {
+		next := make([]int, 0, len(values)*s)
+		for _, value := range values {
+			for digit := 1; digit <= s; digit++ {
+				next = append(next, value*10+digit)
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+// distribution returns the exact distribution of the values, where every combination of digits is
+// equally likely. The critical damage rule does not apply to digit dice.
+func (dd *digitDice) distribution(rollType RollType, _ CriticalDamageRule) distribution {
+	dist := distribution{0: 1}
+	for _, s := range dd.sides {
+		next := make(distribution, len(dist)*s)
+		for value, p := range dist {
+			for digit := 1; digit <= s; digit++ {
+				next[value*10+digit] += p / float64(s)
+			}
+		}
+		dist = next
+	}
+	return dist.withRollType(rollType)
+}
+
+// String returns a string representation of the dice, such as `d66`.
+func (dd *digitDice) String() string {
+	return dd.Str()
+}
+
+// Str returns a string representation of the dice, including the source if there is one.
+func (dd *digitDice) Str() string {
+	var sb strings.Builder
+	sb.WriteString(dd.notation())
+	if dd.source != "" {
+		sb.WriteString(" (")
+		sb.WriteString(dd.source)
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+// notation returns the dice notation for the dice, such as `d66`.
+func (dd *digitDice) notation() string {
+	var sb strings.Builder
+	sb.WriteString("d")
+	for _, s := range dd.sides {
+		sb.WriteString(strconv.Itoa(s))
+	}
+	return sb.String()
+}
+
+// Digits returns the value rolled on each die, from the first digit to the last.
+func (dr *digitRoll) Digits() []int {
+	return dr.digits
+}
+
+// Value returns the value of the roll, with each die read as a digit.
+func (dr *digitRoll) Value() int {
+	return dr.value
+}
+
+// Check returns true if the value of the roll is equal to or greater than the value.
+func (dr *digitRoll) Check(v Value) bool {
+	return dr.value >= v.Value()
+}
+
+// IsCriticalHit returns false, as digit dice do not roll critical hits.
+func (dr *digitRoll) IsCriticalHit() bool {
+	return false
+}
+
+// IsCriticalMiss returns false, as digit dice do not roll critical misses.
+func (dr *digitRoll) IsCriticalMiss() bool {
+	return false
+}
+
+// GetAllRolls returns the individual rolls if the dice were rolled with advantage or disadvantage.
+// Otherwise, a slice with the roll as its only element is returned.
+func (dr *digitRoll) GetAllRolls() []Roll {
+	if len(dr.rolls) == 0 {
+		return []Roll{dr}
+	}
+	rolls := make([]Roll, 0, len(dr.rolls))
+	for _, r := range dr.rolls {
+		rolls = append(rolls, r)
+	}
+	return rolls
+}
+
+// RolledWithAdvantage returns `true` if the roll was made with advantage; `false` otherwise
+func (dr *digitRoll) RolledWithAdvantage() bool {
+	return dr.rollType == RollWithAdvantage
+}
+
+// RolledWithDisadvantage returns `true` if the roll was made with disadvantage; `false` otherwise
+func (dr *digitRoll) RolledWithDisadvantage() bool {
+	return dr.rollType == RollWithDisadvantage
+}
+
+// ReRoll re-rolls the dice with the provided options, returning the new Roll.
+func (dr *digitRoll) ReRoll(opts ...RollOption) Roll {
+	return dr.dice.Roll(opts...)
+}
+
+// GetType gets the type of roll (ROLL_ONCE, ROLL_WITH_ADVANTAGE, ROLL_WITH_DISADVANTATE)
+func (dr *digitRoll) GetType() RollType {
+	return dr.rollType
+}
+
+// GetDice gets the dice that was used for this roll.
+func (dr *digitRoll) GetDice() Dice {
+	return dr.dice
+}
+
+// String returns a string representation of the roll, such as `35 (d66: 3, 5) = 35`.
+func (dr *digitRoll) String() string {
+	return dr.Str() + " = " + strconv.Itoa(dr.value)
+}
+
+// Str returns a string representation of the roll, showing the digit rolled on each die but not the
+// final value.
+func (dr *digitRoll) Str() string {
+	var sb strings.Builder
+	sb.WriteString(strconv.Itoa(dr.value))
+	sb.WriteString(" (")
+	sb.WriteString(dr.dice.notation())
+	sb.WriteString(":")
+	for i, digit := range dr.digits {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(" ")
+		sb.WriteString(strconv.Itoa(digit))
+	}
+	if dr.dice.source != "" {
+		sb.WriteString(", ")
+		sb.WriteString(dr.dice.source)
+	}
+	switch {
+	case dr.RolledWithAdvantage():
+		sb.WriteString(", Advantage")
+	case dr.RolledWithDisadvantage():
+		sb.WriteString(", Disadvantage")
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
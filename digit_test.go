@@ -0,0 +1,166 @@
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestParseDigitDice tests parsing digit dice
+func TestParseDigitDice(t *testing.T) {
+	tests := []struct {
+		str      string
+		expected string
+		err      error
+	}{
+		{"d66", "d66", nil},
+		{"D666", "d666", nil},
+		{" d36 ", "d36", nil},
+		{"d6", "", ErrInvalidDigitDice},
+		{"d61", "", ErrInvalidDigitDice},
+		{"d6x", "", ErrInvalidDigitDice},
+		{"2d66", "", ErrInvalidDigitDice},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDigitDice(test.str)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: expected error %v, got %v", test.str, test.err, err)
+		} else if err == nil && d.String() != test.expected {
+			t.Errorf("%s: expected `%s`, got `%s`", test.str, test.expected, d)
+		}
+	}
+
+	// ParseDice always reads the notation as a single die
+	for _, str := range []string{"d66", "d22", "d444", "d36"} {
+		d, ok := ParseDice(str).(*dice)
+		if !ok || d.NumDice() != 1 || fmt.Sprintf("d%d", d.NumSides()) != str {
+			t.Errorf("Expected %s to be a single die, got %v", str, d)
+		}
+	}
+
+	// Only the source can be set on digit dice
+	d, err := ParseDigitDice("d66", WithSource("Rumors"))
+	if err != nil || d.(*digitDice).source != "Rumors" {
+		t.Errorf("Expected d66 with a source, got %v (%v)", d, err)
+	}
+	for _, opt := range []DiceOption{WithModifier(1), WithLuck(), AsDebuff(), WithDamageType(Fire), WithShuffleBag(1)} {
+		if _, err := ParseDigitDice("d66", opt); !errors.Is(err, ErrInvalidDigitDice) {
+			t.Errorf("Expected %v, got %v", ErrInvalidDigitDice, err)
+		}
+	}
+}
+
+// TestDigitDiceRoll tests rolling digit dice
+func TestDigitDiceRoll(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		r := D66.Roll().(DigitRoll)
+		digits := r.Digits()
+		if len(digits) != 2 || digits[0] < 1 || digits[0] > 6 || digits[1] < 1 || digits[1] > 6 {
+			t.Fatalf("Unexpected digits %v", digits)
+		}
+		if r.Value() != digits[0]*10+digits[1] {
+			t.Errorf("Expected the value to be read from the digits %v, got %d", digits, r.Value())
+		}
+		if D66.GetRoll() != r {
+			t.Errorf("Expected the last roll to be saved")
+		}
+	}
+
+	r := D666.Roll(WithAdvantage())
+	rolls := r.GetAllRolls()
+	if len(rolls) != 2 || !r.RolledWithAdvantage() || r.Value() != max(rolls[0].Value(), rolls[1].Value()) {
+		t.Errorf("Expected the highest of two rolls, got %s", r)
+	}
+	r = D666.Roll(WithDisadvantage())
+	rolls = r.GetAllRolls()
+	if len(rolls) != 2 || !r.RolledWithDisadvantage() || r.Value() != min(rolls[0].Value(), rolls[1].Value()) {
+		t.Errorf("Expected the lowest of two rolls, got %s", r)
+	}
+}
+
+// TestDigitDiceString tests the string representation of digit dice and their rolls
+func TestDigitDiceString(t *testing.T) {
+	d, err := NewDigitDice([]int{3, 6}, WithSource("Rumors"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d.String() != "d36 (Rumors)" || d.NumDice() != 2 || d.NumSides() != 18 {
+		t.Errorf("Unexpected dice %s", d)
+	}
+
+	r := d.Roll().(DigitRoll)
+	digits := r.Digits()
+	expected := fmt.Sprintf("%d%d (d36: %d, %d, Rumors) = %d%d", digits[0], digits[1], digits[0], digits[1], digits[0], digits[1])
+	if r.String() != expected {
+		t.Errorf("Expected `%s`, got `%s`", expected, r)
+	}
+	if _, err := NewDigitDice([]int{6}); !errors.Is(err, ErrInvalidDigitDice) {
+		t.Errorf("Expected %v, got %v", ErrInvalidDigitDice, err)
+	}
+}
+
+// TestDigitDiceTable tests keying a random table with digit dice
+func TestDigitDiceTable(t *testing.T) {
+	dist := diceDistribution(D66)
+	if len(dist) != 36 || !almostEqual(dist[11], 1.0/36) || dist[17] != 0 || !almostEqual(dist.mean(), 38.5) {
+		t.Errorf("Unexpected distribution %v", dist)
+	}
+
+	entries := make([]TableEntry, 0, 6)
+	for tens := 1; tens <= 6; tens++ {
+		entries = append(entries, TableEntry{Min: tens*10 + 1, Max: tens*10 + 6, Text: "Row"})
+	}
+	if _, err := NewTable("Rumors", D66, entries...); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := NewTable("Rumors", D66, entries[1:]...); !errors.Is(err, ErrTableGap) {
+		t.Errorf("Expected %v, got %v", ErrTableGap, err)
+	}
+
+	table, err := ParseTable(TableCSV, "Rumors", []byte("dice,range,text\nd66,11-36,Low\n,41-66,High\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if table.Dice().String() != "d66" {
+		t.Errorf("Expected d66, got %s", table.Dice())
+	}
+}
+
+// TestDigitDiceValues tests the distribution and fixed values of digit dice
+func TestDigitDiceValues(t *testing.T) {
+	dist := diceDistribution(D66)
+	if len(dist) != 36 {
+		t.Errorf("Expected 36 values, got %d", len(dist))
+	}
+	for tens := 1; tens <= 6; tens++ {
+		for units := 1; units <= 6; units++ {
+			if !almostEqual(dist[tens*10+units], 1.0/36) {
+				t.Errorf("Expected %d to have a probability of 1/36, got %f", tens*10+units, dist[tens*10+units])
+			}
+		}
+	}
+
+	d36, _ := ParseDigitDice("d36")
+	tests := []struct {
+		dice    Dice
+		passive int
+		maximum int
+		average int
+	}{
+		{D66, 38, 66, 39},
+		{D666, 388, 666, 389},
+		{d36, 23, 36, 24},
+	}
+	for _, test := range tests {
+		if value := Passive(test.dice).Value(); value != test.passive {
+			t.Errorf("Expected passive of %s to be %d, got %d", test.dice, test.passive, value)
+		}
+		if value := newFixedRoll(fixedMaximum, test.dice).Value(); value != test.maximum {
+			t.Errorf("Expected maximum of %s to be %d, got %d", test.dice, test.maximum, value)
+		}
+		if value := newFixedRoll(fixedAverage, test.dice).Value(); value != test.average {
+			t.Errorf("Expected average of %s to be %d, got %d", test.dice, test.average, value)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package dice
+
+// distribution is the exact probability distribution of the values of a roll, mapping each
+// possible value to the probability of it being rolled.
+type distribution map[int]float64
+
+// distributor is implemented by dice that can calculate the exact distribution of their rolls.
+type distributor interface {
+	distribution(rollType RollType, rule CriticalDamageRule) distribution
+}
+
+// diceDistribution returns the exact distribution of the values returned by rolling the dice with
+// the provided options, including any critical damage rule.
+func diceDistribution(d Dice, opts ...RollOption) distribution {
+	r := &roll{
+		rollType: RollOnce,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return distributionOf(d, r.rollType, r.criticalDamage)
+}
+
+// distributionOf returns the exact distribution of the values returned by rolling the dice with
+// the given roll type and critical damage rule.
+func distributionOf(d Dice, rollType RollType, rule CriticalDamageRule) distribution {
+	if dd, ok := d.(distributor); ok {
+		return dd.distribution(rollType, rule)
+	}
+
+	// Fall back to the properties exposed by the Dice interface
+	dist := criticalDistribution(d.NumDice(), d.NumSides(), d.Modifier(), d.IsLucky(), rule)
+	dist = dist.withRollType(rollType)
+	if d.IsDebuff() {
+		dist = dist.negate()
+	}
+	return dist
+}
+
+// distribution returns the exact distribution of the values returned by rolling the dice.
+func (d *dice) distribution(rollType RollType, rule CriticalDamageRule) distribution {
+	dist := criticalDistribution(d.numDice, d.numSides, d.modifier, d.isLucky, rule)
+	dist = dist.withRollType(rollType)
+	if d.isDebuff {
+		dist = dist.negate()
+	}
+	return dist
+}
+
+// distribution returns the exact distribution of the values returned by rolling the dice set. As
+// with Roll, the roll type only applies to the first dice in the set, while the critical damage rule
+// applies to every dice.
+func (ds diceSet) distribution(rollType RollType, rule CriticalDamageRule) distribution {
+	dist := distribution{0: 1}
+	for i, d := range ds {
+		if i == 0 {
+			dist = dist.add(distributionOf(d, rollType, rule))
+		} else {
+			dist = dist.add(distributionOf(d, RollOnce, rule))
+		}
+	}
+
+	// If all dice in the set are debuffs, a positive total is negated
+	if ds.IsDebuff() {
+		negated := make(distribution, len(dist))
+		for value, p := range dist {
+			if value > 0 {
+				value = -value
+			}
+			negated[value] += p
+		}
+		dist = negated
+	}
+
+	return dist
+}
+
+// sumDistribution returns the distribution of the sum of a number of multi-sided dice plus a
+// constant modifier. Lucky dice re-roll a 1 a single time.
+func sumDistribution(numDice int, numSides int, modifier int, isLucky bool) distribution {
+	dist := distribution{modifier: 1}
+	if numDice <= 0 || numSides <= 0 {
+		return dist
+	}
+
+	face := make(distribution, numSides)
+	p := 1 / float64(numSides)
+	for i := 1; i <= numSides; i++ {
+		face[i] = p
+	}
+	if isLucky {
+		// A 1 is re-rolled, so its probability is spread across all faces
+		face[1] = p * p
+		for i := 2; i <= numSides; i++ {
+			face[i] = p + p*p
+		}
+	}
+
+	for range numDice {
+		dist = dist.add(face)
+	}
+
+	return dist
+}
+
+// criticalDistribution returns the distribution of the sum of a number of multi-sided dice plus a
+// constant modifier, with the critical damage rule applied as is done when the dice are rolled.
+func criticalDistribution(numDice int, numSides int, modifier int, isLucky bool, rule CriticalDamageRule) distribution {
+	switch rule {
+	case CriticalDoubleDice:
+		return sumDistribution(numDice*2, numSides, modifier, isLucky)
+	case CriticalMaxPlusRoll:
+		return sumDistribution(numDice, numSides, modifier+numDice*numSides, isLucky)
+	case CriticalTimesTwo:
+		return sumDistribution(numDice, numSides, modifier, isLucky).times(2)
+	case CriticalTimesThree:
+		return sumDistribution(numDice, numSides, modifier, isLucky).times(3)
+	default:
+		return sumDistribution(numDice, numSides, modifier, isLucky)
+	}
+}
+
+// add returns the distribution of the sum of two independent distributions.
+func (dist distribution) add(other distribution) distribution {
+	sum := make(distribution, len(dist)+len(other))
+	for v1, p1 := range dist {
+		for v2, p2 := range other {
+			sum[v1+v2] += p1 * p2
+		}
+	}
+	return sum
+}
+
+// negate returns the distribution with each value negated.
+func (dist distribution) negate() distribution {
+	negated := make(distribution, len(dist))
+	for value, p := range dist {
+		negated[-value] = p
+	}
+	return negated
+}
+
+// times returns the distribution with each value multiplied by the factor.
+func (dist distribution) times(factor int) distribution {
+	multiplied := make(distribution, len(dist))
+	for value, p := range dist {
+		multiplied[value*factor] += p
+	}
+	return multiplied
+}
+
+// atLeast returns the distribution with each value less than the minimum raised to the minimum.
+func (dist distribution) atLeast(minimum int) distribution {
+	clamped := make(distribution, len(dist))
+	for value, p := range dist {
+		clamped[max(value, minimum)] += p
+	}
+	return clamped
+}
+
+// merge adds the probabilities of the other distribution, scaled by the weight, to the distribution.
+func (dist distribution) merge(other distribution, weight float64) {
+	for value, p := range other {
+		dist[value] += p * weight
+	}
+}
+
+// withRollType returns the distribution of the value kept when rolling with the given roll type.
+// Rolling with advantage keeps the highest of two rolls, and rolling with disadvantage keeps the
+// lowest of two rolls.
+func (dist distribution) withRollType(rollType RollType) distribution {
+	switch rollType {
+	case RollWithAdvantage:
+		return dist.keep(func(v1, v2 int) int { return max(v1, v2) })
+	case RollWithDisadvantage:
+		return dist.keep(func(v1, v2 int) int { return min(v1, v2) })
+	default:
+		return dist
+	}
+}
+
+// keep returns the distribution of the value kept from two independent rolls of the distribution.
+func (dist distribution) keep(choose func(v1, v2 int) int) distribution {
+	kept := make(distribution, len(dist))
+	for v1, p1 := range dist {
+		for v2, p2 := range dist {
+			kept[choose(v1, v2)] += p1 * p2
+		}
+	}
+	return kept
+}
+
+// mean returns the expected value of the distribution.
+func (dist distribution) mean() float64 {
+	var mean float64
+	for value, p := range dist {
+		mean += float64(value) * p
+	}
+	return mean
+}
+
+// compare returns the probability that a value from the distribution is greater than, equal to,
+// and less than a value from the other independent distribution.
+func (dist distribution) compare(other distribution) (greater float64, equal float64, less float64) {
+	for v1, p1 := range dist {
+		for v2, p2 := range other {
+			switch {
+			case v1 > v2:
+				greater += p1 * p2
+			case v1 == v2:
+				equal += p1 * p2
+			default:
+				less += p1 * p2
+			}
+		}
+	}
+	return greater, equal, less
+}
@@ -0,0 +1,90 @@
+package dice
+
+import (
+	"math"
+	"testing"
+)
+
+// almostEqual returns true if the two probabilities are equal, within rounding errors
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// TestSumDistribution tests the distribution of the sum of multiple dice
+func TestSumDistribution(t *testing.T) {
+	dist := sumDistribution(2, 6, 1, false)
+	if len(dist) != 11 {
+		t.Errorf("Expected 11 values for 2d6+1, got %d", len(dist))
+	}
+	if !almostEqual(dist[8], 6.0/36.0) {
+		t.Errorf("Expected P(8) for 2d6+1 to be %f, got %f", 6.0/36.0, dist[8])
+	}
+	if !almostEqual(dist.mean(), 8) {
+		t.Errorf("Expected mean of 2d6+1 to be 8, got %f", dist.mean())
+	}
+
+	// A constant only has one value
+	dist = sumDistribution(0, 0, 5, false)
+	if len(dist) != 1 || dist[5] != 1 {
+		t.Errorf("Expected constant 5 to always be 5, got %v", dist)
+	}
+}
+
+// TestLuckyDistribution tests the distribution of a lucky dice
+func TestLuckyDistribution(t *testing.T) {
+	dist := sumDistribution(1, 6, 0, true)
+	if !almostEqual(dist[1], 1.0/36.0) {
+		t.Errorf("Expected P(1) for a lucky d6 to be %f, got %f", 1.0/36.0, dist[1])
+	}
+	if !almostEqual(dist[6], 7.0/36.0) {
+		t.Errorf("Expected P(6) for a lucky d6 to be %f, got %f", 7.0/36.0, dist[6])
+	}
+}
+
+// TestDistributionRollType tests the distribution when rolling with advantage or disadvantage
+func TestDistributionRollType(t *testing.T) {
+	dist := diceDistribution(D20, WithAdvantage())
+	if !almostEqual(dist[20], 39.0/400.0) {
+		t.Errorf("Expected P(20) with advantage to be %f, got %f", 39.0/400.0, dist[20])
+	}
+	if !almostEqual(dist.mean(), 13.825) {
+		t.Errorf("Expected mean with advantage to be 13.825, got %f", dist.mean())
+	}
+
+	dist = diceDistribution(D20, WithDisadvantage())
+	if !almostEqual(dist[1], 39.0/400.0) {
+		t.Errorf("Expected P(1) with disadvantage to be %f, got %f", 39.0/400.0, dist[1])
+	}
+
+	dist = diceDistribution(D20, WithAdvantage(), WithDisadvantage())
+	if !almostEqual(dist[20], 1.0/20.0) {
+		t.Errorf("Expected P(20) with advantage and disadvantage to be %f, got %f", 1.0/20.0, dist[20])
+	}
+}
+
+// TestDistributionMatchesRoll tests that the distribution covers every value a roll can return
+func TestDistributionMatchesRoll(t *testing.T) {
+	dice := []Dice{
+		ParseDice("2d4+1"),
+		ParseDice("1d6", AsDebuff()),
+		NewDiceSet(NewDice(1, 8), NewConstant(2), NewDice(1, 4, AsDebuff())),
+		NewDiceSet(NewDice(1, 4, AsDebuff()), NewDice(1, 6, AsDebuff())),
+	}
+
+	for _, d := range dice {
+		dist := diceDistribution(d, WithAdvantage())
+		var total float64
+		for _, p := range dist {
+			total += p
+		}
+		if !almostEqual(total, 1) {
+			t.Errorf("Expected probabilities for %s to sum to 1, got %f", d, total)
+		}
+		for i := 0; i < 100; i++ {
+			r := d.Roll(WithAdvantage())
+			if _, ok := dist[r.Value()]; !ok {
+				t.Errorf("Roll %s has a value not in the distribution for %s", r, d)
+			}
+		}
+	}
+}
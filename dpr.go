@@ -0,0 +1,241 @@
+package dice
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DamageProfile is the exact distribution of the damage dealt in a round of attacks.
+type DamageProfile interface {
+	Mean() float64                  // The expected damage
+	Distribution() map[int]float64  // The probability of dealing each amount of damage
+	Probability(damage int) float64 // The probability of dealing exactly the damage
+	AtLeast(damage int) float64     // The probability of dealing at least the damage
+	Min() int                       // The least damage that can be dealt
+	Max() int                       // The most damage that can be dealt
+	fmt.Stringer                    // String representation of the damage profile
+}
+
+// damageProfile is an implementation of the DamageProfile interface.
+type damageProfile struct {
+	dist distribution // The distribution of the damage
+}
+
+// dprConfig holds the options used when calculating the damage per round.
+type dprConfig struct {
+	opts   []RollOption // The options used when rolling to hit
+	riders []Dice       // The damage added to the first hit in the round
+}
+
+// DPROption is a function that can modify the default values used when calculating the damage per round.
+type DPROption func(*dprConfig)
+
+// attackChances holds the chance of each outcome of an attack.
+type attackChances struct {
+	critical float64 // The chance of a critical hit
+	hit      float64 // The chance of a hit that is not a critical hit
+	miss     float64 // The chance of a miss
+}
+
+// DamagePerRound calculates the exact distribution of the damage dealt by the attacks against the
+// armor class. Each attack hits and scores critical hits exactly as it would when resolved, and damage
+// from a critical hit uses the attack's critical damage rule. Once-per-turn riders, such as Sneak
+// Attack, are added to the first attack that hits.
+func DamagePerRound(m Multiattack, ac DifficultyClass, opts ...DPROption) DamageProfile {
+	cfg := &dprConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// Track the damage separately for rounds where the riders have not yet been used and where
+	// they have, so that riders are only added to the first hit
+	unused := distribution{0: 1}
+	used := distribution{}
+	for _, a := range m.Attacks() {
+		chances := attackChancesOf(a, ac, cfg.opts...)
+		damage := distributionOf(a.Damage(), RollOnce, 0).atLeast(0)
+		critical := distributionOf(a.Damage(), RollOnce, a.CriticalDamage()).atLeast(0)
+		riders := distribution{0: 1}
+		criticalRiders := distribution{0: 1}
+		for _, rider := range cfg.riders {
+			riders = riders.add(distributionOf(rider, RollOnce, 0).atLeast(0))
+			criticalRiders = criticalRiders.add(distributionOf(rider, RollOnce, a.CriticalDamage()).atLeast(0))
+		}
+
+		nextUnused := make(distribution, len(unused))
+		nextUnused.merge(unused, chances.miss)
+
+		nextUsed := make(distribution, len(used))
+		nextUsed.merge(used, chances.miss)
+		nextUsed.merge(used.add(damage), chances.hit)
+		nextUsed.merge(used.add(critical), chances.critical)
+		nextUsed.merge(unused.add(damage).add(riders), chances.hit)
+		nextUsed.merge(unused.add(critical).add(criticalRiders), chances.critical)
+
+		unused, used = nextUnused, nextUsed
+	}
+
+	dist := make(distribution, len(used))
+	dist.merge(unused, 1)
+	dist.merge(used, 1)
+	for value, p := range dist {
+		if p == 0 {
+			delete(dist, value)
+		}
+	}
+
+	return &damageProfile{
+		dist: dist,
+	}
+}
+
+// AttackHitChance returns the exact probability that the attack hits the armor class, and the
+// probability that it is a critical hit. The chance to hit includes critical hits.
+func AttackHitChance(a Attack, ac DifficultyClass, opts ...RollOption) (hit float64, critical float64) {
+	chances := attackChancesOf(a, ac, opts...)
+	return chances.hit + chances.critical, chances.critical
+}
+
+// WithDPRRollOptions sets the options used when rolling to hit with each attack, such as WithAdvantage.
+func WithDPRRollOptions(opts ...RollOption) DPROption {
+	return func(cfg *dprConfig) {
+		cfg.opts = append(cfg.opts, opts...)
+	}
+}
+
+// WithRider adds once-per-turn damage, such as Sneak Attack, to the first attack that hits. The rider
+// uses the critical damage rule of the attack it is added to.
+func WithRider(damage Dice) DPROption {
+	return func(cfg *dprConfig) {
+		cfg.riders = append(cfg.riders, damage)
+	}
+}
+
+// attackChancesOf returns the chance of each outcome when the attack is made against the armor class.
+// As with Resolve, only the first dice rolled to hit determines a critical hit or miss.
+func attackChancesOf(a Attack, ac DifficultyClass, opts ...RollOption) attackChances {
+	rollOpts := []RollOption{WithCriticalHitAllowed()}
+	if aa, ok := a.(*attack); ok {
+		rollOpts = append(rollOpts, aa.opts...)
+	}
+	toHit := a.ToHit()
+	if pd, ok := toHit.(*presetDice); ok {
+		rollOpts = append(rollOpts, pd.opts...)
+		toHit = pd.Dice
+	}
+	rollOpts = append(rollOpts, opts...)
+
+	r := &roll{
+		rollType:     RollOnce,
+		criticalHit:  CriticalHit,
+		criticalMiss: CriticalMiss,
+	}
+	for _, opt := range rollOpts {
+		opt(r)
+	}
+
+	// An empty set of dice rolls a flat 0, which cannot be a critical hit
+	allDice := toHit.GetDice()
+	if len(allDice) == 0 {
+		allDice = []Dice{NewConstant(0)}
+	}
+	first := allDice[0]
+	rest := distributionOf(NewDiceSet(allDice[1:]...), RollOnce, 0)
+	firstDice, isDice := first.(*dice)
+	canCritical := r.criticalHitAllowed && isDice && firstDice.isD20()
+
+	var chances attackChances
+	for natural, p1 := range distributionOf(first, r.rollType, 0) {
+		switch {
+		case canCritical && natural >= r.criticalHit:
+			chances.critical += p1
+		case canCritical && natural <= r.criticalMiss:
+			chances.miss += p1
+		default:
+			for modifier, p2 := range rest {
+				if natural+modifier >= ac.Value() {
+					chances.hit += p1 * p2
+				} else {
+					chances.miss += p1 * p2
+				}
+			}
+		}
+	}
+
+	return chances
+}
+
+// Mean returns the expected damage.
+func (dp *damageProfile) Mean() float64 {
+	return dp.dist.mean()
+}
+
+// Distribution returns the probability of dealing each amount of damage.
+func (dp *damageProfile) Distribution() map[int]float64 {
+	dist := make(map[int]float64, len(dp.dist))
+	for value, p := range dp.dist {
+		dist[value] = p
+	}
+	return dist
+}
+
+// Probability returns the probability of dealing exactly the damage.
+func (dp *damageProfile) Probability(damage int) float64 {
+	return dp.dist[damage]
+}
+
+// AtLeast returns the probability of dealing at least the damage.
+func (dp *damageProfile) AtLeast(damage int) float64 {
+	var p float64
+	for value, pv := range dp.dist {
+		if value >= damage {
+			p += pv
+		}
+	}
+	return p
+}
+
+// Min returns the least damage that can be dealt.
+func (dp *damageProfile) Min() int {
+	values := dp.values()
+	if len(values) == 0 {
+		return 0
+	}
+	return values[0]
+}
+
+// Max returns the most damage that can be dealt.
+func (dp *damageProfile) Max() int {
+	values := dp.values()
+	if len(values) == 0 {
+		return 0
+	}
+	return values[len(values)-1]
+}
+
+// values returns the amounts of damage that can be dealt, in ascending order.
+func (dp *damageProfile) values() []int {
+	values := make([]int, 0, len(dp.dist))
+	for value := range dp.dist {
+		values = append(values, value)
+	}
+	sort.Ints(values)
+	return values
+}
+
+// String returns a string representation of the damage profile, such as `DPR 9.35 (0-38)`.
+func (dp *damageProfile) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("DPR ")
+	sb.WriteString(strconv.FormatFloat(dp.Mean(), 'f', 2, 64))
+	sb.WriteString(" (")
+	sb.WriteString(strconv.Itoa(dp.Min()))
+	sb.WriteString("-")
+	sb.WriteString(strconv.Itoa(dp.Max()))
+	sb.WriteString(")")
+
+	return sb.String()
+}
@@ -0,0 +1,151 @@
+package dice
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCriticalDistribution tests the distribution of damage rolled with a critical damage rule
+func TestCriticalDistribution(t *testing.T) {
+	tests := []struct {
+		rule     CriticalDamageRule
+		mean     float64
+		min, max int
+	}{
+		{0, 5.5, 3, 8},
+		{CriticalDoubleDice, 9, 4, 14},
+		{CriticalMaxPlusRoll, 11.5, 9, 14},
+		{CriticalTimesTwo, 11, 6, 16},
+		{CriticalTimesThree, 16.5, 9, 24},
+	}
+
+	for _, test := range tests {
+		dist := diceDistribution(ParseDice("1d6+2"), WithCriticalDamage(test.rule))
+		if !almostEqual(dist.mean(), test.mean) {
+			t.Errorf("Expected mean of 1d6+2 with %s to be %f, got %f", test.rule, test.mean, dist.mean())
+		}
+		if dist[test.min] == 0 || dist[test.max] == 0 || dist[test.min-1] != 0 || dist[test.max+1] != 0 {
+			t.Errorf("Expected 1d6+2 with %s to range from %d to %d, got %v", test.rule, test.min, test.max, dist)
+		}
+	}
+
+	// Constants in a dice set are only changed by rules that multiply the total
+	ds := NewDiceSet(ParseDice("1d6"), NewConstant(2))
+	if dist := diceDistribution(ds, WithCriticalDamage(CriticalDoubleDice)); !almostEqual(dist.mean(), 9) {
+		t.Errorf("Expected mean of 1d6 + 2 with %s to be 9, got %f", CriticalDoubleDice, dist.mean())
+	}
+	if dist := diceDistribution(ds, WithCriticalDamage(CriticalTimesTwo)); !almostEqual(dist.mean(), 11) {
+		t.Errorf("Expected mean of 1d6 + 2 with %s to be 11, got %f", CriticalTimesTwo, dist.mean())
+	}
+}
+
+// TestAttackHitChance tests the chance for an attack to hit and to be a critical hit
+func TestAttackHitChance(t *testing.T) {
+	tests := []struct {
+		attack   Attack
+		opts     []RollOption
+		hit      float64
+		critical float64
+	}{
+		{NewAttack(ParseDice("1d20+5"), ParseDice("1d8+3")), nil, 0.55, 0.05},
+		{NewAttack(ParseDice("1d20+5"), ParseDice("1d8+3")), []RollOption{WithAdvantage()}, 0.7975, 0.0975},
+		{NewAttack(ParseDice("1d20+5"), ParseDice("1d8+3")), []RollOption{WithCriticalHit(19)}, 0.55, 0.1},
+		{NewAttack(ParseDice("1d20+5"), ParseDice("1d8+3"), WithAttackRollOptions(WithCriticalHit(19))), nil, 0.55, 0.1},
+		{NewAttack(ParseDice("1d20+30"), ParseDice("1d8+3")), nil, 0.95, 0.05},
+		{NewAttack(ParseDice("1d20-10"), ParseDice("1d8+3")), nil, 0.05, 0.05},
+		{NewAttack(NewPresetDice(ParseDice("1d20+5"), WithAdvantage()), ParseDice("1d8+3")), nil, 0.7975, 0.0975},
+		{NewAttack(NewDiceSet(), ParseDice("1d8")), nil, 0, 0},
+	}
+
+	for _, test := range tests {
+		hit, critical := AttackHitChance(test.attack, NewDifficultyClass(15), test.opts...)
+		if !almostEqual(hit, test.hit) || !almostEqual(critical, test.critical) {
+			t.Errorf("Expected %s to hit with %f and crit with %f, got %f and %f", test.attack, test.hit, test.critical, hit, critical)
+		}
+	}
+}
+
+// TestDamagePerRound tests the expected damage for a single attack
+func TestDamagePerRound(t *testing.T) {
+	a := NewAttack(ParseDice("1d20+5"), ParseDice("1d8+3"))
+	ac := NewDifficultyClass(15)
+
+	tests := []struct {
+		opts []DPROption
+		mean float64
+	}{
+		{nil, 0.5*7.5 + 0.05*12},
+		{[]DPROption{WithDPRRollOptions(WithAdvantage())}, 0.7*7.5 + 0.0975*12},
+		{[]DPROption{WithDPRRollOptions(WithCriticalHit(19))}, 0.45*7.5 + 0.1*12},
+	}
+	for _, test := range tests {
+		dpr := DamagePerRound(NewMultiattack(a), ac, test.opts...)
+		if !almostEqual(dpr.Mean(), test.mean) {
+			t.Errorf("Expected mean damage %f, got %s", test.mean, dpr)
+		}
+	}
+
+	dpr := DamagePerRound(NewMultiattack(a), ac)
+	if !almostEqual(dpr.Probability(0), 0.45) {
+		t.Errorf("Expected a 0.45 chance of no damage, got %f", dpr.Probability(0))
+	}
+	if dpr.Min() != 0 || dpr.Max() != 19 {
+		t.Errorf("Expected damage to range from 0 to 19, got %s", dpr)
+	}
+	if !almostEqual(dpr.AtLeast(17), 0.05*6.0/64.0) {
+		t.Errorf("Expected a %f chance of at least 17 damage, got %f", 0.05*6.0/64.0, dpr.AtLeast(17))
+	}
+
+	var total float64
+	for _, p := range dpr.Distribution() {
+		total += p
+	}
+	if !almostEqual(total, 1) {
+		t.Errorf("Expected the probabilities to total 1, got %f", total)
+	}
+}
+
+// TestDamagePerRoundMultiattack tests the expected damage for multiple attacks with a once-per-turn rider
+func TestDamagePerRoundMultiattack(t *testing.T) {
+	a := NewAttack(ParseDice("1d20+5"), NewConstant(5))
+	m := NewMultiattack(a, a)
+	ac := NewDifficultyClass(15)
+
+	dpr := DamagePerRound(m, ac)
+	if !almostEqual(dpr.Mean(), 5.5) {
+		t.Errorf("Expected mean damage 5.5, got %s", dpr)
+	}
+
+	// The rider is added to the first hit, and is doubled on a critical hit
+	rider := 0.5*3.5 + 0.05*7
+	dpr = DamagePerRound(m, ac, WithRider(ParseDice("1d6")))
+	if !almostEqual(dpr.Mean(), 5.5+rider+0.45*rider) {
+		t.Errorf("Expected mean damage %f, got %s", 5.5+rider+0.45*rider, dpr)
+	}
+	if dpr.Max() != 22 {
+		t.Errorf("Expected maximum damage of 22, got %d", dpr.Max())
+	}
+	if dpr.String() != "DPR 8.54 (0-22)" && dpr.String() != "DPR 8.55 (0-22)" {
+		t.Errorf("Expected `DPR 8.55 (0-22)`, got `%s`", dpr)
+	}
+}
+
+// TestDamagePerRoundSimulated compares the expected damage to the average of resolved attacks
+func TestDamagePerRoundSimulated(t *testing.T) {
+	m := NewMultiattack(
+		NewAttack(ParseDice("1d20+7"), ParseDice("2d6+4"), WithAttackRollOptions(WithCriticalHit(19))),
+		NewAttack(ParseDice("1d20+7"), ParseDice("1d6+4"), WithAttackCriticalDamage(CriticalMaxPlusRoll)),
+	)
+	ac := NewDifficultyClass(16)
+	dpr := DamagePerRound(m, ac, WithDPRRollOptions(WithDisadvantage()))
+
+	const iterations = 20000
+	var total int
+	for i := 0; i < iterations; i++ {
+		total += m.Resolve(ac, WithDisadvantage()).Damage()
+	}
+	average := float64(total) / iterations
+	if math.Abs(average-dpr.Mean()) > 0.3 {
+		t.Errorf("Expected average damage near %f, got %f", dpr.Mean(), average)
+	}
+}
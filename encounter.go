@@ -0,0 +1,275 @@
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrInvalidEncounterCheck = errors.New("invalid encounter check")
+
+// Day is a day of in-game time, used to find the time of day for encounter checks and modifiers.
+const Day = 24 * time.Hour
+
+// EncounterCheck is a check for a random encounter that is made on a schedule, such as a 1 in 6 chance
+// every watch.
+type EncounterCheck struct {
+	Name      string        // The name of the check, such as `Watch`
+	Dice      Dice          // The dice rolled for the check, such as 1d6
+	Threshold int           // An encounter happens if the roll is equal to or less than the threshold plus any modifiers
+	Every     time.Duration // The time between checks, such as 4 hours for a check every watch
+	Offset    time.Duration // The time of the first check in a day, such as 2 hours for checks at 02:00, 06:00 and so on
+}
+
+// EncounterModifier changes the threshold of encounter checks made in a terrain during part of the day,
+// such as +1 at night in a swamp.
+type EncounterModifier struct {
+	Terrain string        // The terrain the modifier applies to; empty for every terrain
+	From    time.Duration // The time of day the modifier starts
+	To      time.Duration // The time of day the modifier ends; before From if it wraps past midnight, or equal for all day
+	Value   int           // The value added to the threshold
+}
+
+// Encounter is an encounter triggered by an encounter check.
+type Encounter interface {
+	Time() time.Duration   // The in-game time of the check
+	Check() EncounterCheck // The check that triggered the encounter
+	CheckRoll() Roll       // The roll of the check
+	Modifier() int         // The sum of the modifiers added to the threshold of the check
+	Result() TableResult   // The result of rolling on the terrain's table
+	Rolls() []Roll         // The roll of the check, followed by the rolls on the tables
+	fmt.Stringer           // String representation of the encounter
+}
+
+// EncounterChecker makes encounter checks over a span of in-game time and rolls on the terrain's table
+// for each encounter.
+type EncounterChecker interface {
+	Run(terrain string, start time.Duration, span time.Duration) ([]Encounter, error) // Makes the checks during the span
+	Modifier(terrain string, at time.Duration) int                                    // The sum of the modifiers at the time
+}
+
+// encounterChecker is an implementation of the EncounterChecker interface.
+type encounterChecker struct {
+	checks    []EncounterCheck    // The checks that are made
+	tables    map[string]Table    // The encounter table for each terrain
+	modifiers []EncounterModifier // The modifiers added to the threshold of the checks
+	random    *rand.Rand          // The random number generator for the rolls; the global one if nil
+}
+
+// encounter is an implementation of the Encounter interface.
+type encounter struct {
+	time     time.Duration  // The in-game time of the check
+	check    EncounterCheck // The check that triggered the encounter
+	roll     Roll           // The roll of the check
+	modifier int            // The sum of the modifiers added to the threshold
+	result   TableResult    // The result of rolling on the terrain's table
+}
+
+// scheduledCheck is a check that is made at a time.
+type scheduledCheck struct {
+	time  time.Duration // The in-game time of the check
+	check int           // The index of the check
+}
+
+// EncounterOption is a function that can modify the default values of an encounter checker.
+type EncounterOption func(*encounterChecker)
+
+// NewEncounterChecker creates an encounter checker that makes the checks and rolls on the table for the
+// terrain when an encounter happens. An error is returned if a check has no dice or is not made at a
+// regular interval.
+func NewEncounterChecker(checks []EncounterCheck, tables map[string]Table, opts ...EncounterOption) (EncounterChecker, error) {
+	ec := &encounterChecker{
+		checks: make([]EncounterCheck, 0, len(checks)),
+		tables: make(map[string]Table, len(tables)),
+	}
+	for i, check := range checks {
+		if check.Dice == nil {
+			return nil, fmt.Errorf("%w: check %d has no dice", ErrInvalidEncounterCheck, i)
+		}
+		if check.Every <= 0 {
+			return nil, fmt.Errorf("%w: check %d is made every %s", ErrInvalidEncounterCheck, i, check.Every)
+		}
+		ec.checks = append(ec.checks, check)
+	}
+	for terrain, t := range tables {
+		ec.tables[terrain] = t
+	}
+	for _, opt := range opts {
+		opt(ec)
+	}
+
+	return ec, nil
+}
+
+// WithEncounterModifiers adds modifiers to the threshold of the checks, such as for the time of day or
+// the terrain.
+func WithEncounterModifiers(modifiers ...EncounterModifier) EncounterOption {
+	return func(ec *encounterChecker) {
+		ec.modifiers = append(ec.modifiers, modifiers...)
+	}
+}
+
+// WithEncounterSource makes the rolls for the checks and tables with the random number generator instead
+// of the global one, so that the same encounters are made each time the checker is run with a generator
+// seeded the same way, without changing the rolls of other callers. Shuffle bags are refilled from the
+// generator the first time they are used with it. The generator is not safe for concurrent use, so it
+// should not be shared with other goroutines.
+func WithEncounterSource(source *rand.Rand) EncounterOption {
+	return func(ec *encounterChecker) {
+		ec.random = source
+	}
+}
+
+// Run makes every check scheduled from the start up to, but not including, the end of the span, in the
+// order they are made, and rolls on the terrain's table for each check that triggers an encounter. The
+// start is the in-game time since midnight of the first day. Use WithEncounterSource to get the same
+// encounters each time. An error is returned if there is no table for the terrain.
+func (ec *encounterChecker) Run(terrain string, start time.Duration, span time.Duration) ([]Encounter, error) {
+	t, ok := ec.tables[terrain]
+	if !ok {
+		return nil, fmt.Errorf("%w: no table for terrain %s", ErrTableNotFound, terrain)
+	}
+
+	var opts []RollOption
+	if ec.random != nil {
+		opts = append(opts, WithRandomSource(ec.random))
+	}
+
+	var encounters []Encounter
+	for _, sc := range ec.schedule(start, start+span) {
+		check := ec.checks[sc.check]
+		modifier := ec.Modifier(terrain, sc.time)
+		r := check.Dice.Roll(opts...)
+		if r.Value() > check.Threshold+modifier {
+			continue
+		}
+		encounters = append(encounters, &encounter{
+			time:     sc.time,
+			check:    check,
+			roll:     r,
+			modifier: modifier,
+			result:   t.Roll(opts...),
+		})
+	}
+	return encounters, nil
+}
+
+// schedule returns the checks made from the start up to, but not including, the end, ordered by time
+// and then by the order of the checks.
+func (ec *encounterChecker) schedule(start time.Duration, end time.Duration) []scheduledCheck {
+	var scheduled []scheduledCheck
+	for i, check := range ec.checks {
+		// Integer division rounds towards zero, so this is the first check at or after the start, or
+		// the last one before it
+		at := check.Offset + (start-check.Offset)/check.Every*check.Every
+		if at < start {
+			at += check.Every
+		}
+		for ; at < end; at += check.Every {
+			scheduled = append(scheduled, scheduledCheck{time: at, check: i})
+		}
+	}
+	sort.SliceStable(scheduled, func(i, j int) bool {
+		return scheduled[i].time < scheduled[j].time
+	})
+	return scheduled
+}
+
+// Modifier returns the sum of the modifiers that apply in the terrain at the in-game time.
+func (ec *encounterChecker) Modifier(terrain string, at time.Duration) int {
+	timeOfDay := at % Day
+	if timeOfDay < 0 {
+		timeOfDay += Day
+	}
+
+	modifier := 0
+	for _, m := range ec.modifiers {
+		if m.Terrain != "" && m.Terrain != terrain {
+			continue
+		}
+		var applies bool
+		switch {
+		case m.From < m.To:
+			applies = timeOfDay >= m.From && timeOfDay < m.To
+		case m.From > m.To:
+			applies = timeOfDay >= m.From || timeOfDay < m.To
+		default:
+			applies = true
+		}
+		if applies {
+			modifier += m.Value
+		}
+	}
+	return modifier
+}
+
+// Time returns the in-game time of the check.
+func (e *encounter) Time() time.Duration {
+	return e.time
+}
+
+// Check returns the check that triggered the encounter.
+func (e *encounter) Check() EncounterCheck {
+	return e.check
+}
+
+// CheckRoll returns the roll of the check.
+func (e *encounter) CheckRoll() Roll {
+	return e.roll
+}
+
+// Modifier returns the sum of the modifiers added to the threshold of the check.
+func (e *encounter) Modifier() int {
+	return e.modifier
+}
+
+// Result returns the result of rolling on the terrain's table.
+func (e *encounter) Result() TableResult {
+	return e.result
+}
+
+// Rolls returns the roll of the check, followed by the rolls on the tables in the order they were made.
+func (e *encounter) Rolls() []Roll {
+	return appendTableRolls([]Roll{e.roll}, e.result)
+}
+
+// appendTableRolls appends the roll of the table result, followed by the rolls of its further results.
+func appendTableRolls(rolls []Roll, tr TableResult) []Roll {
+	rolls = append(rolls, tr.Roll())
+	for _, result := range tr.Results() {
+		rolls = appendTableRolls(rolls, result)
+	}
+	return rolls
+}
+
+// String returns a string representation of the encounter, such as
+// `Day 1 06:00 Watch: 1 (1d6) = 1: Forest: 7 (2d6) = 7: Wolves`.
+func (e *encounter) String() string {
+	var sb strings.Builder
+	sb.WriteString(formatGameTime(e.time))
+	sb.WriteString(" ")
+	if e.check.Name != "" {
+		sb.WriteString(e.check.Name)
+		sb.WriteString(": ")
+	}
+	sb.WriteString(e.roll.String())
+	sb.WriteString(": ")
+	sb.WriteString(e.result.String())
+	return sb.String()
+}
+
+// formatGameTime formats an in-game time since midnight of the first day, such as `Day 2 14:30`.
+func formatGameTime(t time.Duration) string {
+	day := t / Day
+	timeOfDay := t % Day
+	if timeOfDay < 0 {
+		day--
+		timeOfDay += Day
+	}
+	hours := timeOfDay / time.Hour
+	minutes := (timeOfDay % time.Hour) / time.Minute
+	return fmt.Sprintf("Day %d %02d:%02d", day+1, hours, minutes)
+}
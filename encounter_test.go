@@ -0,0 +1,218 @@
+package dice
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// hexcrawl returns an encounter checker for a hexcrawl for testing
+func hexcrawl(t *testing.T, opts ...EncounterOption) EncounterChecker {
+	t.Helper()
+	forest, err := NewTable("Forest", D4,
+		TableEntry{Min: 1, Max: 2, Text: "Wolves"},
+		TableEntry{Min: 3, Max: 4, Text: "Bandits"},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	swamp, err := NewTable("Swamp", NewConstant(1), TableEntry{Min: 1, Max: 1, Text: "Lizardfolk"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	checks := []EncounterCheck{
+		{Name: "Watch", Dice: D6, Threshold: 1, Every: 4 * time.Hour},
+	}
+	ec, err := NewEncounterChecker(checks, map[string]Table{"forest": forest, "swamp": swamp}, opts...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return ec
+}
+
+// TestNewEncounterChecker tests that invalid checks are rejected
+func TestNewEncounterChecker(t *testing.T) {
+	tests := []EncounterCheck{
+		{Name: "No dice", Every: time.Hour},
+		{Name: "Never", Dice: D6},
+		{Name: "Backwards", Dice: D6, Every: -time.Hour},
+	}
+	for _, check := range tests {
+		_, err := NewEncounterChecker([]EncounterCheck{check}, nil)
+		if !errors.Is(err, ErrInvalidEncounterCheck) {
+			t.Errorf("%s: expected ErrInvalidEncounterCheck, got %v", check.Name, err)
+		}
+	}
+}
+
+// TestEncounterRun tests running the checks over a span of time
+func TestEncounterRun(t *testing.T) {
+	ec := hexcrawl(t)
+	if _, err := ec.Run("desert", 0, Day); !errors.Is(err, ErrTableNotFound) {
+		t.Errorf("Expected ErrTableNotFound, got %v", err)
+	}
+
+	total := 0
+	for range 1000 {
+		encounters, err := ec.Run("forest", 0, Day)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		total += len(encounters)
+		for _, e := range encounters {
+			if e.Time()%(4*time.Hour) != 0 || e.Time() >= Day {
+				t.Errorf("Unexpected time %s", e.Time())
+			}
+			if e.CheckRoll().Value() != 1 {
+				t.Errorf("Expected an encounter on a 1, got %s", e.CheckRoll())
+			}
+			if text := e.Result().Text(); text[0] != "Wolves" && text[0] != "Bandits" {
+				t.Errorf("Unexpected encounter %v", text)
+			}
+			if len(e.Rolls()) != 2 {
+				t.Errorf("Expected the check and table rolls, got %d", len(e.Rolls()))
+			}
+		}
+	}
+
+	// Six checks a day with a 1 in 6 chance each
+	if total < 850 || total > 1150 {
+		t.Errorf("Expected about 1000 encounters, got %d", total)
+	}
+}
+
+// TestEncounterSchedule tests the times that checks are made
+func TestEncounterSchedule(t *testing.T) {
+	ec := &encounterChecker{
+		checks: []EncounterCheck{
+			{Name: "Watch", Dice: D6, Every: 4 * time.Hour, Offset: 2 * time.Hour},
+			{Name: "Night", Dice: D6, Every: Day, Offset: 22 * time.Hour},
+		},
+	}
+
+	scheduled := ec.schedule(20*time.Hour, 30*time.Hour)
+	expected := []scheduledCheck{
+		{22 * time.Hour, 0},
+		{22 * time.Hour, 1},
+		{26 * time.Hour, 0},
+	}
+	if len(scheduled) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, scheduled)
+	}
+	for i, sc := range expected {
+		if scheduled[i] != sc {
+			t.Errorf("Expected check %d to be %v, got %v", i, sc, scheduled[i])
+		}
+	}
+
+	if scheduled := ec.schedule(2*time.Hour, 2*time.Hour); len(scheduled) != 0 {
+		t.Errorf("Expected no checks in an empty span, got %v", scheduled)
+	}
+}
+
+// TestEncounterModifier tests modifiers for the time of day and terrain
+func TestEncounterModifier(t *testing.T) {
+	ec := hexcrawl(t, WithEncounterModifiers(
+		EncounterModifier{From: 20 * time.Hour, To: 6 * time.Hour, Value: 1},
+		EncounterModifier{Terrain: "swamp", Value: 1},
+		EncounterModifier{Terrain: "forest", From: 12 * time.Hour, To: 14 * time.Hour, Value: -1},
+	))
+
+	tests := []struct {
+		terrain  string
+		at       time.Duration
+		expected int
+	}{
+		{"forest", 0, 1},
+		{"forest", 6 * time.Hour, 0},
+		{"forest", 12 * time.Hour, -1},
+		{"forest", Day + 21*time.Hour, 1},
+		{"swamp", 0, 2},
+		{"swamp", 12 * time.Hour, 1},
+	}
+	for _, test := range tests {
+		if modifier := ec.Modifier(test.terrain, test.at); modifier != test.expected {
+			t.Errorf("%s at %s: expected %d, got %d", test.terrain, test.at, test.expected, modifier)
+		}
+	}
+
+	// At night in the swamp, encounters happen on a 1 to 3
+	for range 100 {
+		encounters, err := ec.Run("swamp", 0, 4*time.Hour)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, e := range encounters {
+			if e.Modifier() != 2 || e.CheckRoll().Value() > 3 {
+				t.Errorf("Unexpected encounter %s with modifier %d", e, e.Modifier())
+			}
+		}
+	}
+}
+
+// TestEncounterSource tests that the encounters are the same when run with a random number generator
+// seeded the same way, and that the global random number generator is not used
+func TestEncounterSource(t *testing.T) {
+	forest, err := NewTable("Forest", ParseDice("1d4", WithShuffleBag(2)),
+		TableEntry{Min: 1, Max: 2, Text: "Wolves"},
+		TableEntry{Min: 3, Max: 4, Text: "Bandits"},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checks := []EncounterCheck{
+		{Name: "Watch", Dice: ParseDice("1d6", WithShuffleBag(1)), Threshold: 2, Every: 4 * time.Hour},
+	}
+	run := func() []string {
+		ec, err := NewEncounterChecker(checks, map[string]Table{"forest": forest}, WithEncounterSource(rand.New(rand.NewSource(7))))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		// The shuffle bags are partly drawn between runs
+		encounters, err := ec.Run("forest", 0, 10*Day+4*time.Hour)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		text := make([]string, 0, len(encounters))
+		for _, e := range encounters {
+			text = append(text, e.String())
+		}
+		return text
+	}
+
+	Seed(7)
+	expected := D20.Roll().Value()
+	Seed(7)
+	first, second := run(), run()
+	if len(first) == 0 || len(first) != len(second) {
+		t.Fatalf("Expected the same encounters, got %v and %v", first, second)
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("Expected encounter %d to be %q, got %q", i, first[i], second[i])
+		}
+	}
+	if value := D20.Roll().Value(); value != expected {
+		t.Errorf("Expected the global random number generator to be unchanged, got %d instead of %d", value, expected)
+	}
+}
+
+// TestEncounterString tests the string representation of an encounter
+func TestEncounterString(t *testing.T) {
+	swamp, err := NewTable("Swamp", NewConstant(1), TableEntry{Min: 1, Max: 1, Text: "Lizardfolk"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	e := &encounter{
+		time:   Day + 14*time.Hour + 30*time.Minute,
+		check:  EncounterCheck{Name: "Watch"},
+		roll:   NewConstant(1).Roll(),
+		result: swamp.Roll(),
+	}
+	expected := "Day 2 14:30 Watch: 1 = 1: Swamp: 1 = 1: Lizardfolk"
+	if e.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, e.String())
+	}
+}
@@ -0,0 +1,175 @@
+package dice
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Default delimiters that surround a dice expression in text
+const (
+	DefaultExpandOpen  = "[["
+	DefaultExpandClose = "]]"
+)
+
+// expressionPattern matches a dice expression inside delimiters, such as `2d4`, `1d6+1`, `1d6+1d4` or
+// `3d6×10`. Expressions without dice, such as `{name}` or `{10}`, are rejected by rollExpression, and
+// dice without sides, such as `1d0`, are not matched, so that they are left in the text.
+var expressionPattern = regexp.MustCompile(`(?i)^\s*([+-]?\s*(?:\d*d0*[1-9]\d*|\d+)(?:\s*[+-]\s*(?:\d*d0*[1-9]\d*|\d+))*)\s*(?:[x×*]\s*(\d+))?\s*$`)
+
+// expressionTermPattern matches a single term of a dice expression, such as `1d6`, `-1d4` or `+2`.
+var expressionTermPattern = regexp.MustCompile(`[+-]?(?:\d*d0*[1-9]\d*|\d+)`)
+
+// Expansion is the result of expanding the dice expressions in text.
+type Expansion interface {
+	Template() string // The text before the dice expressions were expanded
+	Text() string     // The text with each dice expression replaced by the value rolled
+	Rolls() []Roll    // The rolls made, in the order they appear in the text
+	fmt.Stringer      // String representation of the expansion, including the rolls
+}
+
+// expansion is an implementation of the Expansion interface.
+type expansion struct {
+	template string // The text before the dice expressions were expanded
+	text     string // The text with each dice expression replaced by the value rolled
+	rolls    []Roll // The rolls made
+}
+
+// expandConfig is the configuration used when expanding dice expressions in text.
+type expandConfig struct {
+	delimiters [][2]string  // The pairs of opening and closing delimiters
+	rollOpts   []RollOption // The options used when rolling each expression
+}
+
+// ExpandOption is a function that can modify the default values used when expanding text.
+type ExpandOption func(*expandConfig)
+
+// ExpandText finds the dice expressions in the text, rolls them, and replaces each with the value rolled.
+// By default, expressions are surrounded by `[[` and `]]` or by `{` and `}`, such as `[[2d4]] goblins`
+// or `{3d6×10} gp`. An expression may add or subtract several dice and constants, such as `{1d6+1d4}`,
+// and may be followed by a multiplier written as `×10`, `x10` or `*10`. Delimited text that is not a
+// dice expression is left unchanged.
+func ExpandText(text string, opts ...ExpandOption) Expansion {
+	cfg := &expandConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if len(cfg.delimiters) == 0 {
+		cfg.delimiters = [][2]string{{DefaultExpandOpen, DefaultExpandClose}, {"{", "}"}}
+	}
+
+	alternatives := make([]string, 0, len(cfg.delimiters))
+	for _, delim := range cfg.delimiters {
+		alternatives = append(alternatives, regexp.QuoteMeta(delim[0])+"(.+?)"+regexp.QuoteMeta(delim[1]))
+	}
+	pattern := regexp.MustCompile(strings.Join(alternatives, "|"))
+
+	e := &expansion{
+		template: text,
+	}
+	e.text = pattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := pattern.FindStringSubmatch(match)
+		var expr string
+		for _, group := range groups[1:] {
+			if group != "" {
+				expr = group
+				break
+			}
+		}
+		value, r, ok := rollExpression(expr, cfg.rollOpts)
+		if !ok {
+			return match
+		}
+		e.rolls = append(e.rolls, r)
+		return strconv.Itoa(value)
+	})
+
+	return e
+}
+
+// ExpandTableText expands the dice expressions in the text of each entry selected by the table result.
+func ExpandTableText(result TableResult, opts ...ExpandOption) []Expansion {
+	text := result.Text()
+	expansions := make([]Expansion, 0, len(text))
+	for _, t := range text {
+		expansions = append(expansions, ExpandText(t, opts...))
+	}
+	return expansions
+}
+
+// WithExpandDelimiters sets the opening and closing delimiters that surround a dice expression. This
+// replaces the default delimiters, and may be used more than once to allow several pairs.
+func WithExpandDelimiters(opening string, closing string) ExpandOption {
+	return func(cfg *expandConfig) {
+		cfg.delimiters = append(cfg.delimiters, [2]string{opening, closing})
+	}
+}
+
+// WithExpandRollOptions sets the options used when rolling each dice expression.
+func WithExpandRollOptions(opts ...RollOption) ExpandOption {
+	return func(cfg *expandConfig) {
+		cfg.rollOpts = append(cfg.rollOpts, opts...)
+	}
+}
+
+// rollExpression rolls the dice expression, returning the value, which includes any multiplier, and
+// the roll. If the expression is not a dice expression, `false` is returned.
+func rollExpression(expr string, opts []RollOption) (int, Roll, bool) {
+	match := expressionPattern.FindStringSubmatch(expr)
+	if match == nil || !strings.ContainsAny(match[1], "dD") {
+		return 0, nil, false
+	}
+
+	r := parseExpression(strings.ReplaceAll(match[1], " ", "")).Roll(opts...)
+	value := r.Value()
+	if match[2] != "" {
+		multiplier, _ := strconv.Atoi(match[2])
+		value *= multiplier
+	}
+	return value, r, true
+}
+
+// parseExpression returns the dice for a dice expression. An expression with a single dice, optionally
+// followed by a modifier, is a single dice such as `2d6+3`. Otherwise, each term is a dice in a dice set,
+// so that `1d6+1d4` rolls both dice.
+func parseExpression(expr string) Dice {
+	terms := expressionTermPattern.FindAllString(expr, -1)
+	if len(terms) == 1 || (len(terms) == 2 && strings.ContainsAny(terms[0], "dD") && !strings.ContainsAny(terms[1], "dD")) {
+		return ParseDice(expr)
+	}
+
+	dice := make([]Dice, 0, len(terms))
+	for _, term := range terms {
+		dice = append(dice, ParseDice(term))
+	}
+	return NewDiceSet(dice...)
+}
+
+// Template returns the text before the dice expressions were expanded.
+func (e *expansion) Template() string {
+	return e.template
+}
+
+// Text returns the text with each dice expression replaced by the value rolled.
+func (e *expansion) Text() string {
+	return e.text
+}
+
+// Rolls returns the rolls made, in the order they appear in the text.
+func (e *expansion) Rolls() []Roll {
+	return e.rolls
+}
+
+// String returns a string representation of the expansion, such as `5 goblins [5 (2d4) = 5]`.
+func (e *expansion) String() string {
+	if len(e.rolls) == 0 {
+		return e.text
+	}
+
+	rolls := make([]string, 0, len(e.rolls))
+	for _, r := range e.rolls {
+		rolls = append(rolls, r.String())
+	}
+	return e.text + " [" + strings.Join(rolls, ", ") + "]"
+}
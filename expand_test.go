@@ -0,0 +1,109 @@
+package dice
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestExpandText tests expanding dice expressions in text
+func TestExpandText(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		e := ExpandText("[[2d4]] goblins and {3d6×10} gp")
+		rolls := e.Rolls()
+		if len(rolls) != 2 {
+			t.Fatalf("Expected 2 rolls, got %d", len(rolls))
+		}
+		goblins, gold := rolls[0].Value(), rolls[1].Value()*10
+		if goblins < 2 || goblins > 8 || gold < 30 || gold > 180 {
+			t.Errorf("Unexpected rolls %v", rolls)
+		}
+		expected := strconv.Itoa(goblins) + " goblins and " + strconv.Itoa(gold) + " gp"
+		if e.Text() != expected {
+			t.Errorf("Expected `%s`, got `%s`", expected, e.Text())
+		}
+		if e.Template() != "[[2d4]] goblins and {3d6×10} gp" {
+			t.Errorf("Unexpected template `%s`", e.Template())
+		}
+	}
+}
+
+// TestExpandTextExpressions tests the expressions that are expanded
+func TestExpandTextExpressions(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"{1d1+2} arrows", "3 arrows"},
+		{"[[ 2d1 - 1 ]] rats", "1 rats"},
+		{"{D1x5} gp", "5 gp"},
+		{"{1d1 * 10} sp", "10 sp"},
+		{"{-1d1}", "-1"},
+		{"{name} has {10} coins", "{name} has {10} coins"},
+		{"[[2d]] and {d}", "[[2d]] and {d}"},
+		{"no dice", "no dice"},
+		{"{1d1+2d1} bats", "3 bats"},
+		{"{1d1 + 1d1 + 3} bats", "5 bats"},
+		{"{2d1-1d1+1}", "2"},
+		{"{1d1+1d1x2} gp", "4 gp"},
+		{"{10+5}", "{10+5}"},
+		{"{1d0} goblins", "{1d0} goblins"},
+		{"[[d00]] and {1d1+1d0}", "[[d00]] and {1d1+1d0}"},
+		{"{1d01} orc", "1 orc"},
+	}
+
+	for _, test := range tests {
+		if text := ExpandText(test.text).Text(); text != test.expected {
+			t.Errorf("Expected `%s` to expand to `%s`, got `%s`", test.text, test.expected, text)
+		}
+	}
+}
+
+// TestExpandTextDiceSet tests that expressions with several dice roll each of the dice
+func TestExpandTextDiceSet(t *testing.T) {
+	e := ExpandText("{1d6+1d4+1} damage")
+	if len(e.Rolls()) != 1 {
+		t.Fatalf("Expected a single roll, got %d", len(e.Rolls()))
+	}
+	r := e.Rolls()[0]
+	if len(r.GetDice().GetDice()) != 3 || r.Value() < 3 || r.Value() > 11 {
+		t.Errorf("Expected 1d6 + 1d4 + 1 to be rolled, got %s", r)
+	}
+	if e.Text() != strconv.Itoa(r.Value())+" damage" {
+		t.Errorf("Expected the roll to replace the expression, got `%s`", e.Text())
+	}
+}
+
+// TestExpandTextOptions tests custom delimiters and roll options
+func TestExpandTextOptions(t *testing.T) {
+	e := ExpandText("<1d1> and {1d1}", WithExpandDelimiters("<", ">"))
+	if e.Text() != "1 and {1d1}" || len(e.Rolls()) != 1 {
+		t.Errorf("Expected only `<1d1>` to be expanded, got `%s`", e)
+	}
+
+	e = ExpandText("{1d20}", WithExpandRollOptions(WithAdvantage()))
+	if !e.Rolls()[0].RolledWithAdvantage() {
+		t.Errorf("Expected the roll to be made with advantage, got %s", e)
+	}
+
+	e = ExpandText("{1d1} orc")
+	if e.String() != "1 orc [1 (1d1) = 1]" {
+		t.Errorf("Unexpected string `%s`", e)
+	}
+}
+
+// TestExpandTableText tests expanding the text of a table result
+func TestExpandTableText(t *testing.T) {
+	table, err := NewTable("Encounters", NewConstant(1), TableEntry{Min: 1, Max: 1, Text: "{2d1} goblins"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expansions := ExpandTableText(table.Roll())
+	if len(expansions) != 1 || expansions[0].Text() != "2 goblins" {
+		t.Errorf("Expected [2 goblins], got %v", expansions)
+	}
+	if !strings.HasPrefix(expansions[0].String(), "2 goblins [") {
+		t.Errorf("Unexpected string `%s`", expansions[0])
+	}
+}
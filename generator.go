@@ -0,0 +1,448 @@
+package dice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Generator is a node in a procedural generator, such as a weighted choice or a repeated part, that
+// produces a result when generated. Generators are combined into a tree using Text, Amount, Choice,
+// Repeat, Sequence, Named and If, and are run with Generate. The interface is sealed on purpose: a node
+// records its rolls, transcript and named results in state that is internal to the package, so new
+// kinds of nodes are built by combining the provided ones, with conditions written as a
+// GeneratorCondition.
+type Generator interface {
+	generate(ctx *generatorContext) *generatorResult
+}
+
+// GeneratorResult is the result of generating a node, along with the results of its children.
+type GeneratorResult interface {
+	Name() string                       // The name given to the result with Named, if any
+	Text() string                       // The text of the result, including its children
+	Value() int                         // The value of an amount, or the sum of the values of the children
+	Children() []GeneratorResult        // The results of the children
+	Find(name string) []GeneratorResult // The results with the name, in the order they were generated
+	Sum(name string) int                // The sum of the values of the results with the name
+	Rolls() []Roll                      // Every roll made, in the order they were made
+	Transcript() []string               // A line for every roll made, in the order they were made
+	fmt.Stringer                        // String representation of the result, which is its text
+}
+
+// GeneratorContext is the state of a generator while it runs, used by conditions to look at the named
+// results generated so far.
+type GeneratorContext interface {
+	Get(name string) (GeneratorResult, bool) // The most recent result with the name, if there is one
+	Count(name string) int                   // The number of results with the name
+	Sum(name string) int                     // The sum of the values of the results with the name
+}
+
+// GeneratorCondition decides whether a branch of a generator is taken, based on the results generated so far.
+type GeneratorCondition func(ctx GeneratorContext) bool
+
+// WeightedGenerator is a choice in a weighted choice, selected with a probability proportional to its weight.
+type WeightedGenerator struct {
+	Weight    int       // The relative weight of the choice
+	Generator Generator // The generator used when the choice is selected
+}
+
+// generatorContext is an implementation of the GeneratorContext interface.
+type generatorContext struct {
+	named map[string][]*generatorResult // The named results, in the order they were generated
+	label string                        // The name of the innermost named generator, used to label the transcript
+	opts  []RollOption                  // The options used for every roll
+}
+
+// generatorResult is an implementation of the GeneratorResult interface.
+type generatorResult struct {
+	name     string             // The name given to the result
+	text     string             // The text of the result, including its children
+	value    int                // The value of the result
+	rolls    []Roll             // The rolls made for this result, not including its children
+	lines    []string           // The transcript of the rolls made for this result
+	children []*generatorResult // The results of the children
+}
+
+// textGenerator is a generator for literal text, with any inline dice expressions expanded.
+type textGenerator struct {
+	text string // The text, which may include dice expressions such as `{2d4}`
+}
+
+// amountGenerator is a generator for an amount rolled on dice, such as gold.
+type amountGenerator struct {
+	dice       Dice   // The dice rolled for the amount
+	multiplier int    // The value the roll is multiplied by
+	unit       string // The unit written after the amount, such as `gp`
+}
+
+// choiceGenerator is a generator that selects one of its choices at random.
+type choiceGenerator struct {
+	choices []WeightedGenerator // The choices
+	total   int                 // The sum of the weights of the choices
+}
+
+// repeatGenerator is a generator that generates its child a number of times rolled on dice.
+type repeatGenerator struct {
+	count     Dice      // The dice rolled for the number of times
+	generator Generator // The generator that is repeated
+}
+
+// sequenceGenerator is a generator that generates each of its children in order.
+type sequenceGenerator struct {
+	separator  string      // The text written between the text of the children
+	generators []Generator // The children
+}
+
+// namedGenerator is a generator whose result is given a name, so that it can be found, summed and used
+// in conditions.
+type namedGenerator struct {
+	name      string    // The name of the result
+	generator Generator // The generator that is named
+}
+
+// ifGenerator is a generator that generates one of two children depending on a condition.
+type ifGenerator struct {
+	condition GeneratorCondition // The condition that selects the child
+	then      Generator          // The generator used when the condition is true
+	otherwise Generator          // The generator used when the condition is false; may be nil
+}
+
+// Generate runs the generator, returning its result. The options are used for every roll made by the
+// generator, including the dice expressions in text and the rolls that select a choice. Use
+// WithRandomSource to get the same result each time from a generator seeded the same way.
+func Generate(g Generator, opts ...RollOption) GeneratorResult {
+	ctx := &generatorContext{
+		named: make(map[string][]*generatorResult),
+		opts:  opts,
+	}
+	return g.generate(ctx)
+}
+
+// Text returns a generator for the text. Dice expressions in the text, such as `{2d4}` or `[[1d6]]`,
+// are rolled and replaced with the value rolled each time the text is generated.
+func Text(text string) Generator {
+	return &textGenerator{
+		text: text,
+	}
+}
+
+// Amount returns a generator for an amount rolled on the dice and multiplied by the multiplier, such
+// as 3d6×10 gp. The value of the result is the amount, so that amounts can be added up with Sum.
+func Amount(d Dice, multiplier int, unit string) Generator {
+	return &amountGenerator{
+		dice:       d,
+		multiplier: multiplier,
+		unit:       unit,
+	}
+}
+
+// Choice returns a generator that selects one of the choices with a probability proportional to its
+// weight, by rolling a single dice with as many sides as the total weight. Choices with a weight of 0
+// or less are never selected.
+func Choice(choices ...WeightedGenerator) Generator {
+	cg := &choiceGenerator{
+		choices: make([]WeightedGenerator, 0, len(choices)),
+	}
+	for _, choice := range choices {
+		if choice.Weight > 0 {
+			cg.choices = append(cg.choices, choice)
+			cg.total += choice.Weight
+		}
+	}
+	return cg
+}
+
+// Weighted returns a choice with the weight, for use in Choice.
+func Weighted(weight int, g Generator) WeightedGenerator {
+	return WeightedGenerator{
+		Weight:    weight,
+		Generator: g,
+	}
+}
+
+// Repeat returns a generator that generates the child the number of times rolled on the dice, such as
+// 1d4 gems. The text of the results is separated by commas.
+func Repeat(count Dice, g Generator) Generator {
+	return &repeatGenerator{
+		count:     count,
+		generator: g,
+	}
+}
+
+// Sequence returns a generator that generates each child in order, with their text separated by the
+// separator. Children without any text are skipped.
+func Sequence(separator string, generators ...Generator) Generator {
+	sg := &sequenceGenerator{
+		separator:  separator,
+		generators: make([]Generator, 0, len(generators)),
+	}
+	sg.generators = append(sg.generators, generators...)
+	return sg
+}
+
+// Named returns a generator that gives the result of the child the name, so that it can be found and
+// summed in the result, used in conditions, and labelled in the transcript.
+func Named(name string, g Generator) Generator {
+	return &namedGenerator{
+		name:      name,
+		generator: g,
+	}
+}
+
+// If returns a generator that generates the first child if the condition is true, and otherwise the
+// second child. The second child may be nil, in which case nothing is generated.
+func If(condition GeneratorCondition, then Generator, otherwise Generator) Generator {
+	return &ifGenerator{
+		condition: condition,
+		then:      then,
+		otherwise: otherwise,
+	}
+}
+
+// TextIs returns a condition that is true if the most recent result with the name has the text.
+func TextIs(name string, text string) GeneratorCondition {
+	return func(ctx GeneratorContext) bool {
+		result, ok := ctx.Get(name)
+		return ok && result.Text() == text
+	}
+}
+
+// SumAtLeast returns a condition that is true if the values of the results with the name add up to at
+// least the value.
+func SumAtLeast(name string, value int) GeneratorCondition {
+	return func(ctx GeneratorContext) bool {
+		return ctx.Sum(name) >= value
+	}
+}
+
+// generate expands the dice expressions in the text.
+func (tg *textGenerator) generate(ctx *generatorContext) *generatorResult {
+	e := ExpandText(tg.text, WithExpandRollOptions(ctx.opts...))
+	gr := &generatorResult{
+		text:  e.Text(),
+		rolls: e.Rolls(),
+	}
+	for _, r := range gr.rolls {
+		gr.lines = append(gr.lines, ctx.labelled(r.String()))
+	}
+	return gr
+}
+
+// generate rolls the amount.
+func (ag *amountGenerator) generate(ctx *generatorContext) *generatorResult {
+	r := ag.dice.Roll(ctx.opts...)
+	gr := &generatorResult{
+		value: r.Value() * ag.multiplier,
+		rolls: []Roll{r},
+	}
+	gr.text = strings.TrimSpace(strconv.Itoa(gr.value) + " " + ag.unit)
+
+	line := r.String()
+	if ag.multiplier != 1 {
+		line += " ×" + strconv.Itoa(ag.multiplier) + " = " + strconv.Itoa(gr.value)
+	}
+	gr.lines = []string{ctx.labelled(line)}
+	return gr
+}
+
+// generate rolls for one of the choices and generates it.
+func (cg *choiceGenerator) generate(ctx *generatorContext) *generatorResult {
+	if cg.total == 0 {
+		return &generatorResult{}
+	}
+
+	r := NewDice(1, cg.total).Roll(ctx.opts...)
+	value := r.Value()
+	choice := cg.choices[len(cg.choices)-1]
+	for _, c := range cg.choices {
+		if value <= c.Weight {
+			choice = c
+			break
+		}
+		value -= c.Weight
+	}
+
+	child := choice.Generator.generate(ctx)
+	return &generatorResult{
+		text:     child.text,
+		value:    child.value,
+		rolls:    []Roll{r},
+		lines:    []string{ctx.labelled(r.String() + ": " + child.text)},
+		children: []*generatorResult{child},
+	}
+}
+
+// generate rolls the number of times and generates the child that many times.
+func (rg *repeatGenerator) generate(ctx *generatorContext) *generatorResult {
+	r := rg.count.Roll(ctx.opts...)
+	gr := &generatorResult{
+		rolls: []Roll{r},
+		lines: []string{ctx.labelled(r.String() + " times")},
+	}
+	for range max(r.Value(), 0) {
+		gr.children = append(gr.children, rg.generator.generate(ctx))
+	}
+	gr.join(", ")
+	return gr
+}
+
+// generate generates each child in order.
+func (sg *sequenceGenerator) generate(ctx *generatorContext) *generatorResult {
+	gr := &generatorResult{}
+	for _, g := range sg.generators {
+		gr.children = append(gr.children, g.generate(ctx))
+	}
+	gr.join(sg.separator)
+	return gr
+}
+
+// generate generates the child and records its result under the name.
+func (ng *namedGenerator) generate(ctx *generatorContext) *generatorResult {
+	label := ctx.label
+	ctx.label = ng.name
+	child := ng.generator.generate(ctx)
+	ctx.label = label
+
+	gr := &generatorResult{
+		name:     ng.name,
+		text:     child.text,
+		value:    child.value,
+		children: []*generatorResult{child},
+	}
+	ctx.named[ng.name] = append(ctx.named[ng.name], gr)
+	return gr
+}
+
+// generate generates the child selected by the condition.
+func (ig *ifGenerator) generate(ctx *generatorContext) *generatorResult {
+	g := ig.otherwise
+	if ig.condition(ctx) {
+		g = ig.then
+	}
+	if g == nil {
+		return &generatorResult{}
+	}
+
+	child := g.generate(ctx)
+	return &generatorResult{
+		text:     child.text,
+		value:    child.value,
+		children: []*generatorResult{child},
+	}
+}
+
+// join sets the text of the result to the text of its children separated by the separator, skipping
+// children without any text, and the value to the sum of their values.
+func (gr *generatorResult) join(separator string) {
+	text := make([]string, 0, len(gr.children))
+	for _, child := range gr.children {
+		if child.text != "" {
+			text = append(text, child.text)
+		}
+		gr.value += child.value
+	}
+	gr.text = strings.Join(text, separator)
+}
+
+// labelled returns the line prefixed with the name of the innermost named generator, if there is one.
+func (ctx *generatorContext) labelled(line string) string {
+	if ctx.label == "" {
+		return line
+	}
+	return ctx.label + ": " + line
+}
+
+// Get returns the most recent result with the name, and `true` if there is one; `false` otherwise
+func (ctx *generatorContext) Get(name string) (GeneratorResult, bool) {
+	results := ctx.named[name]
+	if len(results) == 0 {
+		return nil, false
+	}
+	return results[len(results)-1], true
+}
+
+// Count returns the number of results with the name.
+func (ctx *generatorContext) Count(name string) int {
+	return len(ctx.named[name])
+}
+
+// Sum returns the sum of the values of the results with the name.
+func (ctx *generatorContext) Sum(name string) int {
+	sum := 0
+	for _, result := range ctx.named[name] {
+		sum += result.value
+	}
+	return sum
+}
+
+// Name returns the name given to the result with Named, if any.
+func (gr *generatorResult) Name() string {
+	return gr.name
+}
+
+// Text returns the text of the result, including its children.
+func (gr *generatorResult) Text() string {
+	return gr.text
+}
+
+// Value returns the value of an amount, or the sum of the values of the children.
+func (gr *generatorResult) Value() int {
+	return gr.value
+}
+
+// Children returns the results of the children.
+func (gr *generatorResult) Children() []GeneratorResult {
+	children := make([]GeneratorResult, 0, len(gr.children))
+	for _, child := range gr.children {
+		children = append(children, child)
+	}
+	return children
+}
+
+// Find returns the results with the name, in the order they were generated. The children of a result
+// with the name are not searched.
+func (gr *generatorResult) Find(name string) []GeneratorResult {
+	if gr.name == name {
+		return []GeneratorResult{gr}
+	}
+	var found []GeneratorResult
+	for _, child := range gr.children {
+		found = append(found, child.Find(name)...)
+	}
+	return found
+}
+
+// Sum returns the sum of the values of the results with the name, such as the total value of all gems.
+func (gr *generatorResult) Sum(name string) int {
+	sum := 0
+	for _, result := range gr.Find(name) {
+		sum += result.Value()
+	}
+	return sum
+}
+
+// Rolls returns every roll made, in the order they were made.
+func (gr *generatorResult) Rolls() []Roll {
+	rolls := make([]Roll, 0, len(gr.rolls))
+	rolls = append(rolls, gr.rolls...)
+	for _, child := range gr.children {
+		rolls = append(rolls, child.Rolls()...)
+	}
+	return rolls
+}
+
+// Transcript returns a line for every roll made, in the order they were made, such as
+// `Gems: 3 (1d4) = 3 times`.
+func (gr *generatorResult) Transcript() []string {
+	lines := make([]string, 0, len(gr.lines))
+	lines = append(lines, gr.lines...)
+	for _, child := range gr.children {
+		lines = append(lines, child.Transcript()...)
+	}
+	return lines
+}
+
+// String returns the text of the result.
+func (gr *generatorResult) String() string {
+	return gr.text
+}
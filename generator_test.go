@@ -0,0 +1,201 @@
+package dice
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// hoard returns a generator for a treasure hoard for testing
+func hoard() Generator {
+	gem := Named("Gem", Choice(
+		Weighted(3, Sequence(" ", Text("Agate"), Named("Gem Value", Amount(NewConstant(10), 1, "gp")))),
+		Weighted(1, Sequence(" ", Text("Ruby"), Named("Gem Value", Amount(NewConstant(50), 1, "gp")))),
+	))
+	return Sequence("; ",
+		Named("Gold", Amount(NewDice(3, 6), 10, "gp")),
+		Named("Gems", Repeat(NewDice(1, 4), gem)),
+		If(SumAtLeast("Gem Value", 100), Text("a jeweller's loupe"), nil),
+	)
+}
+
+// TestGenerateHoard tests generating a hoard with repeats, choices, amounts and a condition
+func TestGenerateHoard(t *testing.T) {
+	for range 200 {
+		result := Generate(hoard())
+
+		gold := result.Sum("Gold")
+		if gold < 30 || gold > 180 || gold%10 != 0 {
+			t.Fatalf("Unexpected gold %d in %s", gold, result)
+		}
+
+		gems := result.Find("Gem")
+		if len(gems) < 1 || len(gems) > 4 {
+			t.Fatalf("Unexpected number of gems %d in %s", len(gems), result)
+		}
+		gemValue := 0
+		for _, gem := range gems {
+			switch gem.Text() {
+			case "Agate 10 gp":
+				gemValue += 10
+			case "Ruby 50 gp":
+				gemValue += 50
+			default:
+				t.Fatalf("Unexpected gem %q", gem.Text())
+			}
+		}
+		if result.Sum("Gem Value") != gemValue {
+			t.Errorf("Expected gem value %d, got %d", gemValue, result.Sum("Gem Value"))
+		}
+		if result.Value() != gold+gemValue {
+			t.Errorf("Expected a value of %d, got %d", gold+gemValue, result.Value())
+		}
+		if strings.HasSuffix(result.Text(), "a jeweller's loupe") != (gemValue >= 100) {
+			t.Errorf("Unexpected condition for gem value %d in %s", gemValue, result)
+		}
+
+		// One roll for the gold, one for the number of gems, and two for each gem
+		if len(result.Rolls()) != 2+2*len(gems) {
+			t.Errorf("Expected %d rolls, got %d", 2+2*len(gems), len(result.Rolls()))
+		}
+		if len(result.Transcript()) != len(result.Rolls()) {
+			t.Errorf("Expected a line for each roll, got %v", result.Transcript())
+		}
+	}
+}
+
+// TestGeneratorTranscript tests the transcript of the rolls made by a generator
+func TestGeneratorTranscript(t *testing.T) {
+	g := Sequence(", ",
+		Named("Gold", Amount(NewConstant(5), 10, "gp")),
+		Named("Room", Choice(Weighted(1, Text("Lair")))),
+		Named("Gems", Repeat(NewConstant(2), Text("Agate"))),
+	)
+	result := Generate(g)
+
+	if result.Text() != "50 gp, Lair, Agate, Agate" {
+		t.Errorf("Unexpected text %q", result.Text())
+	}
+	expected := []string{
+		"Gold: 5 = 5 ×10 = 50",
+		"Room: 1 (1d1) = 1: Lair",
+		"Gems: 2 = 2 times",
+	}
+	transcript := result.Transcript()
+	if len(transcript) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, transcript)
+	}
+	for i, line := range expected {
+		if transcript[i] != line {
+			t.Errorf("Expected line %d to be %q, got %q", i, line, transcript[i])
+		}
+	}
+}
+
+// TestGenerateChoice tests that choices are selected in proportion to their weights
+func TestGenerateChoice(t *testing.T) {
+	g := Choice(Weighted(3, Text("Goblin")), Weighted(1, Text("Orc")), Weighted(0, Text("Dragon")))
+	counts := make(map[string]int)
+	for range 4000 {
+		counts[Generate(g).Text()]++
+	}
+	if counts["Dragon"] != 0 {
+		t.Errorf("Expected a choice with no weight to never be selected, got %d", counts["Dragon"])
+	}
+	if counts["Goblin"] < 2700 || counts["Goblin"] > 3300 {
+		t.Errorf("Expected about 3000 goblins, got %d", counts["Goblin"])
+	}
+
+	if result := Generate(Choice()); result.Text() != "" || len(result.Rolls()) != 0 {
+		t.Errorf("Expected nothing from an empty choice, got %q", result.Text())
+	}
+}
+
+// TestGenerateConditions tests branches that depend on earlier results
+func TestGenerateConditions(t *testing.T) {
+	room := Named("Room", Choice(Weighted(1, Text("Lair")), Weighted(1, Text("Corridor"))))
+	g := Sequence(" with ",
+		room,
+		If(TextIs("Room", "Lair"), Named("Monster", Text("an owlbear")), Text("nothing")),
+	)
+	for range 100 {
+		result := Generate(g)
+		switch result.Text() {
+		case "Lair with an owlbear":
+			if len(result.Find("Monster")) != 1 {
+				t.Errorf("Expected a monster in %s", result)
+			}
+		case "Corridor with nothing":
+			if len(result.Find("Monster")) != 0 {
+				t.Errorf("Expected no monster in %s", result)
+			}
+		default:
+			t.Errorf("Unexpected text %q", result.Text())
+		}
+	}
+
+	ctx := &generatorContext{named: make(map[string][]*generatorResult)}
+	if _, ok := ctx.Get("Room"); ok || ctx.Count("Room") != 0 || ctx.Sum("Room") != 0 {
+		t.Errorf("Expected no results in an empty context")
+	}
+}
+
+// TestGenerateText tests that dice expressions in text are rolled
+func TestGenerateText(t *testing.T) {
+	result := Generate(Named("Goblins", Text("{1d1+2} goblins")))
+	if result.Text() != "3 goblins" {
+		t.Errorf("Expected %q, got %q", "3 goblins", result.Text())
+	}
+	if len(result.Rolls()) != 1 {
+		t.Errorf("Expected one roll, got %d", len(result.Rolls()))
+	}
+	if transcript := result.Transcript(); len(transcript) != 1 || !strings.HasPrefix(transcript[0], "Goblins: 3") {
+		t.Errorf("Unexpected transcript %v", transcript)
+	}
+	if result.Name() != "Goblins" || len(result.Children()) != 1 {
+		t.Errorf("Unexpected result %s", result)
+	}
+}
+
+// TestGenerateRollOptions tests that the options are used for every roll
+func TestGenerateRollOptions(t *testing.T) {
+	g := Sequence(" ",
+		Amount(D20, 1, "gp"),
+		Text("{1d20}"),
+		Repeat(D4, Text("gem")),
+		Choice(Weighted(1, Text("Lair")), Weighted(1, Text("Corridor"))),
+	)
+	result := Generate(g, WithAdvantage())
+	if len(result.Rolls()) != 4 {
+		t.Fatalf("Expected four rolls, got %d", len(result.Rolls()))
+	}
+	for _, r := range result.Rolls() {
+		if !r.RolledWithAdvantage() {
+			t.Errorf("Expected %s to be rolled with advantage", r)
+		}
+	}
+}
+
+// TestGenerateRandomSource tests that a generator run with a random number generator seeded the same way
+// generates the same result, without using the global random number generator
+func TestGenerateRandomSource(t *testing.T) {
+	run := func() []string {
+		return Generate(hoard(), WithRandomSource(rand.New(rand.NewSource(5)))).Transcript()
+	}
+
+	Seed(3)
+	expected := D20.Roll().Value()
+	Seed(3)
+	first, second := run(), run()
+	if len(first) != len(second) {
+		t.Fatalf("Expected the same transcript, got %v and %v", first, second)
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("Expected line %d to be %q, got %q", i, first[i], second[i])
+		}
+	}
+	if value := D20.Roll().Value(); value != expected {
+		t.Errorf("Expected the global random number generator to be unchanged, got %d instead of %d", value, expected)
+	}
+}
@@ -0,0 +1,190 @@
+package dice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GroupPolicy determines how the checks made by each member of a group are combined into the
+// result for the group.
+type GroupPolicy int
+
+const (
+	_                GroupPolicy = iota
+	GroupAtLeastHalf             // The group succeeds if at least half of the members succeed
+	GroupAll                     // The group succeeds only if every member succeeds
+	GroupAny                     // The group succeeds if any member succeeds
+	GroupBestOf                  // The group succeeds if the highest roll succeeds
+	GroupAverage                 // The group succeeds if the average of all rolls succeeds
+)
+
+// GroupResult is the result of a group check.
+type GroupResult interface {
+	Policy() GroupPolicy              // The policy used to combine the checks
+	DifficultyClass() DifficultyClass // The difficulty class the members rolled against
+	Rolls() []Roll                    // The roll for each member, in the same order as the members
+	Passed() []bool                   // Whether each member passed their check, in the same order as the members
+	NumPassed() int                   // The number of members that passed their check
+	Best() Roll                       // The highest roll made by a member of the group
+	Average() int                     // The average of the rolls made by the group, rounded down
+	Success() bool                    // Returns true if the group succeeded
+	fmt.Stringer                      // String representation of the group check
+}
+
+// groupResult is an implementation of the GroupResult interface.
+type groupResult struct {
+	policy GroupPolicy     // The policy used to combine the checks
+	dc     DifficultyClass // The difficulty class the members rolled against
+	rolls  []Roll          // The roll for each member
+	passed []bool          // Whether each member passed their check
+}
+
+// GroupCheck rolls a check for each member of the group against the difficulty class, and combines
+// the results using the policy. Members that roll with their own options, such as advantage, can be
+// created with NewPresetDice.
+func GroupCheck(members []Dice, dc DifficultyClass, policy GroupPolicy) GroupResult {
+	gr := &groupResult{
+		policy: policy,
+		dc:     dc,
+		rolls:  make([]Roll, 0, len(members)),
+		passed: make([]bool, 0, len(members)),
+	}
+	for _, member := range members {
+		r := member.Roll()
+		gr.rolls = append(gr.rolls, r)
+		gr.passed = append(gr.passed, dc.Check(r))
+	}
+
+	return gr
+}
+
+// Policy returns the policy used to combine the checks.
+func (gr *groupResult) Policy() GroupPolicy {
+	return gr.policy
+}
+
+// DifficultyClass returns the difficulty class the members rolled against.
+func (gr *groupResult) DifficultyClass() DifficultyClass {
+	return gr.dc
+}
+
+// Rolls returns the roll for each member of the group.
+func (gr *groupResult) Rolls() []Roll {
+	return gr.rolls
+}
+
+// Passed returns whether each member of the group passed their check.
+func (gr *groupResult) Passed() []bool {
+	return gr.passed
+}
+
+// NumPassed returns the number of members that passed their check.
+func (gr *groupResult) NumPassed() int {
+	var numPassed int
+	for _, passed := range gr.passed {
+		if passed {
+			numPassed++
+		}
+	}
+	return numPassed
+}
+
+// Best returns the highest roll made by a member of the group, or nil if the group has no members.
+// A critical hit is always the best roll.
+func (gr *groupResult) Best() Roll {
+	var best Roll
+	for _, r := range gr.rolls {
+		switch {
+		case best == nil:
+			best = r
+		case best.IsCriticalHit():
+		case r.IsCriticalHit() || r.Value() > best.Value():
+			best = r
+		}
+	}
+	return best
+}
+
+// Average returns the average of the rolls made by the group, rounded down.
+func (gr *groupResult) Average() int {
+	if len(gr.rolls) == 0 {
+		return 0
+	}
+	var total int
+	for _, r := range gr.rolls {
+		total += r.Value()
+	}
+	return total / len(gr.rolls)
+}
+
+// Success returns `true` if the group succeeded; `false` otherwise
+func (gr *groupResult) Success() bool {
+	if len(gr.rolls) == 0 {
+		return false
+	}
+
+	switch gr.policy {
+	case GroupAll:
+		return gr.NumPassed() == len(gr.rolls)
+	case GroupAny:
+		return gr.NumPassed() > 0
+	case GroupBestOf:
+		return gr.dc.Check(gr.Best())
+	case GroupAverage:
+		return gr.dc.Check(NewDifficultyClass(gr.Average()))
+	default:
+		return gr.NumPassed()*2 >= len(gr.rolls)
+	}
+}
+
+// String returns a string representation of the group policy.
+func (p GroupPolicy) String() string {
+	switch p {
+	case GroupAtLeastHalf:
+		return "At Least Half"
+	case GroupAll:
+		return "All"
+	case GroupAny:
+		return "Any"
+	case GroupBestOf:
+		return "Best Of"
+	case GroupAverage:
+		return "Average"
+	default:
+		return "Unknown"
+	}
+}
+
+// String returns a string representation of the group check. This includes the roll for each member,
+// whether they passed, and the result for the group.
+func (gr *groupResult) String() string {
+	var sb strings.Builder
+
+	for i, r := range gr.rolls {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(r.String())
+		if gr.passed[i] {
+			sb.WriteString(" (Pass)")
+		} else {
+			sb.WriteString(" (Fail)")
+		}
+	}
+	sb.WriteString(" vs DC ")
+	sb.WriteString(gr.dc.String())
+	sb.WriteString(": ")
+	sb.WriteString(strconv.Itoa(gr.NumPassed()))
+	sb.WriteString("/")
+	sb.WriteString(strconv.Itoa(len(gr.rolls)))
+	sb.WriteString(" passed, ")
+	sb.WriteString(gr.policy.String())
+	if gr.Success() {
+		sb.WriteString(" succeeds")
+	} else {
+		sb.WriteString(" fails")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,118 @@
+package dice
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGroupCheckPolicies tests combining the checks for a group using each policy
+func TestGroupCheckPolicies(t *testing.T) {
+	members := []Dice{
+		NewConstant(15),
+		NewConstant(8),
+		NewConstant(12),
+		NewConstant(4),
+	}
+	dc := NewDifficultyClass(10)
+
+	tests := []struct {
+		policy   GroupPolicy
+		expected bool
+	}{
+		{GroupAtLeastHalf, true},
+		{GroupAll, false},
+		{GroupAny, true},
+		{GroupBestOf, true},
+		{GroupAverage, false},
+	}
+
+	for _, test := range tests {
+		result := GroupCheck(members, dc, test.policy)
+		if result.Success() != test.expected {
+			t.Errorf("GroupCheck with policy %s = %v; expected %v", test.policy, result.Success(), test.expected)
+		}
+		if result.NumPassed() != 2 {
+			t.Errorf("Expected 2 members to pass, got %d", result.NumPassed())
+		}
+		if result.Best().Value() != 15 {
+			t.Errorf("Expected best roll to be 15, got %d", result.Best().Value())
+		}
+		if result.Average() != 9 {
+			t.Errorf("Expected average roll to be 9, got %d", result.Average())
+		}
+	}
+
+	// Less than half of the group succeeds
+	result := GroupCheck(members, NewDifficultyClass(13), GroupAtLeastHalf)
+	if result.Success() {
+		t.Errorf("Expected group to fail when only one of four members succeeds")
+	}
+
+	// An empty group always fails
+	result = GroupCheck(nil, dc, GroupAny)
+	if result.Success() || result.Best() != nil {
+		t.Errorf("Expected empty group to fail")
+	}
+}
+
+// TestGroupCheckMemberOptions tests members rolling with their own roll options
+func TestGroupCheckMemberOptions(t *testing.T) {
+	members := []Dice{
+		NewPresetDice(D20, WithAdvantage()),
+		D20,
+		NewPresetDice(NewDice(1, 20, WithModifier(2)), WithDisadvantage()),
+	}
+
+	for i := 0; i < 100; i++ {
+		result := GroupCheck(members, NewDifficultyClass(12), GroupAtLeastHalf)
+		rolls := result.Rolls()
+		if len(rolls) != 3 || len(result.Passed()) != 3 {
+			t.Fatalf("Expected 3 rolls, got %d", len(rolls))
+		}
+		if !rolls[0].RolledWithAdvantage() {
+			t.Errorf("Expected first member to roll with advantage")
+		}
+		if rolls[1].RolledWithAdvantage() || rolls[1].RolledWithDisadvantage() {
+			t.Errorf("Expected second member to roll normally")
+		}
+		if !rolls[2].RolledWithDisadvantage() {
+			t.Errorf("Expected third member to roll with disadvantage")
+		}
+		for j, r := range rolls {
+			if result.Passed()[j] != (r.Value() >= 12) {
+				t.Errorf("Expected roll %s to pass = %v", r, r.Value() >= 12)
+			}
+		}
+	}
+}
+
+// TestGroupCheckBestCritical tests that a critical hit is the best roll in a group
+func TestGroupCheckBestCritical(t *testing.T) {
+	crit := &singleRoll{value: 20, dice: D20, criticalHitAllowed: true, criticalHit: CriticalHit, criticalMiss: CriticalMiss}
+	gr := &groupResult{
+		policy: GroupBestOf,
+		dc:     NewDifficultyClass(30),
+		rolls:  []Roll{NewConstant(25).Roll(), crit},
+		passed: []bool{false, true},
+	}
+	if gr.Best() != crit {
+		t.Errorf("Expected the critical hit to be the best roll, got %s", gr.Best())
+	}
+	if !gr.Success() {
+		t.Errorf("Expected a critical hit to succeed for best of")
+	}
+}
+
+// TestGroupCheckString tests the string representation of a group check
+func TestGroupCheckString(t *testing.T) {
+	result := GroupCheck([]Dice{NewConstant(12), NewConstant(8)}, NewDifficultyClass(10), GroupAtLeastHalf)
+	expected := "12 = 12 (Pass), 8 = 8 (Fail) vs DC 10: 1/2 passed, At Least Half succeeds"
+	if result.String() != expected {
+		t.Errorf("Expected `%s`, got `%s`", expected, result.String())
+	}
+
+	result = GroupCheck([]Dice{NewConstant(8)}, NewDifficultyClass(10), GroupAll)
+	if !strings.HasSuffix(result.String(), "All fails") {
+		t.Errorf("Expected group check to fail, got `%s`", result.String())
+	}
+}
@@ -0,0 +1,250 @@
+package dice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HitPointPolicy is the rule used to determine the hit points gained at each level.
+type HitPointPolicy int
+
+const (
+	_                   HitPointPolicy = iota
+	HitPointsRoll                      // The hit die is rolled
+	HitPointsRerollOnes                // The hit die is rolled, re-rolling any 1s
+	HitPointsAverage                   // The average of the hit die, rounded up, is taken
+)
+
+const (
+	DefaultMinimumHitPointsPerLevel = 1 // The fewest hit points gained at a level, even with a negative Con modifier
+)
+
+// ClassLevel is a number of levels in a class, each of which gains hit points using the class's hit die.
+type ClassLevel struct {
+	Class  string // The name of the class
+	Levels int    // The number of levels in the class
+	HitDie Dice   // The hit die for the class, such as D10
+}
+
+// HitPointLevel is the hit points gained at a single character level.
+type HitPointLevel interface {
+	Level() int       // The character level
+	Class() string    // The class the level was taken in
+	Roll() Roll       // The roll of the hit die
+	Rerolls() []Roll  // The rolls of the hit die that were re-rolled because they rolled a 1
+	ConModifier() int // The Con modifier added to the roll
+	HitPoints() int   // The hit points gained at the level
+	fmt.Stringer      // String representation of the level
+}
+
+// HitPoints are the hit points of a character, with the hit points gained at each level.
+type HitPoints interface {
+	Total() int              // The total hit points
+	Levels() []HitPointLevel // The hit points gained at each level, starting with the first
+	Transcript() string      // A line for each level, followed by the total hit points
+	fmt.Stringer             // String representation of the hit points
+}
+
+// hitPointLevel is an implementation of the HitPointLevel interface.
+type hitPointLevel struct {
+	level       int    // The character level
+	class       string // The class the level was taken in
+	roll        Roll   // The roll of the hit die
+	rerolls     []Roll // The rolls of the hit die that rolled a 1
+	conModifier int    // The Con modifier added to the roll
+	hitPoints   int    // The hit points gained at the level
+}
+
+// hitPoints is an implementation of the HitPoints interface.
+type hitPoints struct {
+	classes []ClassLevel     // The class levels the hit points were calculated for
+	levels  []*hitPointLevel // The hit points gained at each level
+}
+
+// hitPointConfig holds the options used when calculating hit points.
+type hitPointConfig struct {
+	policy          HitPointPolicy // The rule used to determine the hit points gained at each level
+	maxFirstLevel   bool           // If true, the first level gains the maximum value of the hit die
+	minimumPerLevel int            // The fewest hit points gained at a level
+}
+
+// HitPointOption is a function that can modify the default values used when calculating hit points.
+type HitPointOption func(*hitPointConfig)
+
+// RollHitPoints calculates the hit points for the class levels, with each level gaining the hit die
+// plus the Con modifier. The first level in the first class gains the maximum value of its hit die, and
+// the remaining levels roll the hit die. Each level gains at least DefaultMinimumHitPointsPerLevel.
+func RollHitPoints(classes []ClassLevel, conModifier int, opts ...HitPointOption) HitPoints {
+	cfg := &hitPointConfig{
+		policy:          HitPointsRoll,
+		maxFirstLevel:   true,
+		minimumPerLevel: DefaultMinimumHitPointsPerLevel,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	hp := &hitPoints{
+		classes: classes,
+	}
+	for _, class := range classes {
+		for range class.Levels {
+			level := &hitPointLevel{
+				level:       len(hp.levels) + 1,
+				class:       class.Class,
+				conModifier: conModifier,
+			}
+
+			switch {
+			case level.level == 1 && cfg.maxFirstLevel:
+				level.roll = newFixedRoll(fixedMaximum, class.HitDie)
+			case cfg.policy == HitPointsAverage:
+				level.roll = newFixedRoll(fixedAverage, class.HitDie)
+			default:
+				level.roll = class.HitDie.Roll()
+				for cfg.policy == HitPointsRerollOnes && level.roll.Value() == 1 && class.HitDie.NumSides() > 1 {
+					level.rerolls = append(level.rerolls, level.roll)
+					level.roll = class.HitDie.Roll()
+				}
+			}
+
+			level.hitPoints = max(level.roll.Value()+conModifier, cfg.minimumPerLevel)
+			hp.levels = append(hp.levels, level)
+		}
+	}
+
+	return hp
+}
+
+// WithHitPointPolicy sets the rule used to determine the hit points gained at each level after the first.
+func WithHitPointPolicy(policy HitPointPolicy) HitPointOption {
+	return func(cfg *hitPointConfig) {
+		cfg.policy = policy
+	}
+}
+
+// WithFirstLevelRolled uses the hit point policy for the first level, rather than gaining the maximum
+// value of the hit die.
+func WithFirstLevelRolled() HitPointOption {
+	return func(cfg *hitPointConfig) {
+		cfg.maxFirstLevel = false
+	}
+}
+
+// WithMinimumHitPointsPerLevel sets the fewest hit points that are gained at a level.
+func WithMinimumHitPointsPerLevel(minimum int) HitPointOption {
+	return func(cfg *hitPointConfig) {
+		cfg.minimumPerLevel = minimum
+	}
+}
+
+// Total returns the total hit points.
+func (hp *hitPoints) Total() int {
+	var total int
+	for _, level := range hp.levels {
+		total += level.hitPoints
+	}
+	return total
+}
+
+// Levels returns the hit points gained at each level, starting with the first.
+func (hp *hitPoints) Levels() []HitPointLevel {
+	levels := make([]HitPointLevel, 0, len(hp.levels))
+	for _, level := range hp.levels {
+		levels = append(levels, level)
+	}
+	return levels
+}
+
+// Level returns the character level.
+func (l *hitPointLevel) Level() int {
+	return l.level
+}
+
+// Class returns the class the level was taken in.
+func (l *hitPointLevel) Class() string {
+	return l.class
+}
+
+// Roll returns the roll of the hit die.
+func (l *hitPointLevel) Roll() Roll {
+	return l.roll
+}
+
+// Rerolls returns the rolls of the hit die that were re-rolled because they rolled a 1.
+func (l *hitPointLevel) Rerolls() []Roll {
+	return l.rerolls
+}
+
+// ConModifier returns the Con modifier added to the roll.
+func (l *hitPointLevel) ConModifier() int {
+	return l.conModifier
+}
+
+// HitPoints returns the hit points gained at the level.
+func (l *hitPointLevel) HitPoints() int {
+	return l.hitPoints
+}
+
+// String returns a string representation of the level, such as `Level 2 (Fighter): 7 (1d10) +2 = 9`.
+func (l *hitPointLevel) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("Level ")
+	sb.WriteString(strconv.Itoa(l.level))
+	if l.class != "" {
+		sb.WriteString(" (")
+		sb.WriteString(l.class)
+		sb.WriteString(")")
+	}
+	sb.WriteString(": ")
+	sb.WriteString(l.roll.Str())
+	sb.WriteString(" ")
+	sb.WriteString(formatModifier(l.conModifier))
+	sb.WriteString(" = ")
+	sb.WriteString(strconv.Itoa(l.hitPoints))
+	if len(l.rerolls) > 0 {
+		sb.WriteString(" - ")
+		sb.WriteString(strconv.Itoa(len(l.rerolls)))
+		sb.WriteString(" rerolled")
+	}
+
+	return sb.String()
+}
+
+// Transcript returns a line for each level, followed by the total hit points.
+func (hp *hitPoints) Transcript() string {
+	var sb strings.Builder
+
+	for _, level := range hp.levels {
+		sb.WriteString(level.String())
+		sb.WriteString("\n")
+	}
+	sb.WriteString("Total: ")
+	sb.WriteString(strconv.Itoa(hp.Total()))
+
+	return sb.String()
+}
+
+// String returns a string representation of the hit points, such as `31 HP (Fighter 3, Wizard 1)`.
+func (hp *hitPoints) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(strconv.Itoa(hp.Total()))
+	sb.WriteString(" HP")
+
+	classes := make([]string, 0, len(hp.classes))
+	for _, class := range hp.classes {
+		if class.Levels > 0 && class.Class != "" {
+			classes = append(classes, class.Class+" "+strconv.Itoa(class.Levels))
+		}
+	}
+	if len(classes) > 0 {
+		sb.WriteString(" (")
+		sb.WriteString(strings.Join(classes, ", "))
+		sb.WriteString(")")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,139 @@
+package dice
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestFixedDiceValues tests the maximum and average values of dice
+func TestFixedDiceValues(t *testing.T) {
+	tests := []struct {
+		dice    Dice
+		maximum int
+		average int
+	}{
+		{D6, 6, 4},
+		{D8, 8, 5},
+		{D10, 10, 6},
+		{D12, 12, 7},
+		{ParseDice("2d6+1"), 13, 8},
+		{NewConstant(3), 3, 3},
+	}
+
+	for _, test := range tests {
+		if value := newFixedRoll(fixedMaximum, test.dice).Value(); value != test.maximum {
+			t.Errorf("Expected maximum of %s to be %d, got %d", test.dice, test.maximum, value)
+		}
+		if value := newFixedRoll(fixedAverage, test.dice).Value(); value != test.average {
+			t.Errorf("Expected average of %s to be %d, got %d", test.dice, test.average, value)
+		}
+	}
+}
+
+// TestRollHitPoints tests rolling hit points for multiple classes
+func TestRollHitPoints(t *testing.T) {
+	classes := []ClassLevel{
+		{Class: "Fighter", Levels: 3, HitDie: D10},
+		{Class: "Wizard", Levels: 2, HitDie: D6},
+	}
+
+	for i := 0; i < 50; i++ {
+		hp := RollHitPoints(classes, 2)
+		levels := hp.Levels()
+		if len(levels) != 5 {
+			t.Fatalf("Expected 5 levels, got %d", len(levels))
+		}
+		if levels[0].HitPoints() != 12 {
+			t.Errorf("Expected 12 hit points at level 1, got %s", levels[0])
+		}
+
+		total := 0
+		for j, level := range levels {
+			if level.Level() != j+1 {
+				t.Errorf("Expected level %d, got %d", j+1, level.Level())
+			}
+			if level.HitPoints() != level.Roll().Value()+2 {
+				t.Errorf("Expected hit points to be the roll plus the Con modifier, got %s", level)
+			}
+			total += level.HitPoints()
+		}
+		if hp.Total() != total {
+			t.Errorf("Expected total of %d, got %d", total, hp.Total())
+		}
+		if levels[3].Class() != "Wizard" || levels[3].HitPoints() < 3 || levels[3].HitPoints() > 8 {
+			t.Errorf("Expected a Wizard level between 3 and 8 hit points, got %s", levels[3])
+		}
+	}
+}
+
+// TestRollHitPointsPolicies tests the hit point policies
+func TestRollHitPointsPolicies(t *testing.T) {
+	classes := []ClassLevel{{Class: "Fighter", Levels: 5, HitDie: D10}}
+
+	hp := RollHitPoints(classes, 1, WithHitPointPolicy(HitPointsAverage))
+	if hp.Total() != 11+4*7 {
+		t.Errorf("Expected %d hit points, got %s", 11+4*7, hp)
+	}
+
+	hp = RollHitPoints(classes, 1, WithHitPointPolicy(HitPointsAverage), WithFirstLevelRolled())
+	if hp.Total() != 5*7 {
+		t.Errorf("Expected %d hit points, got %s", 5*7, hp)
+	}
+
+	for i := 0; i < 50; i++ {
+		hp = RollHitPoints(classes, 0, WithHitPointPolicy(HitPointsRerollOnes))
+		for _, level := range hp.Levels()[1:] {
+			if level.Roll().Value() == 1 {
+				t.Errorf("Expected 1s to be re-rolled, got %s", level)
+			}
+			for _, reroll := range level.Rerolls() {
+				if reroll.Value() != 1 {
+					t.Errorf("Expected only 1s to be re-rolled, got %s", reroll)
+				}
+			}
+		}
+	}
+}
+
+// TestRollHitPointsMinimum tests the minimum hit points gained at each level
+func TestRollHitPointsMinimum(t *testing.T) {
+	classes := []ClassLevel{{Class: "Wizard", Levels: 4, HitDie: D6}}
+
+	for i := 0; i < 50; i++ {
+		hp := RollHitPoints(classes, -3, WithFirstLevelRolled())
+		for _, level := range hp.Levels() {
+			if level.HitPoints() < 1 {
+				t.Errorf("Expected at least 1 hit point, got %s", level)
+			}
+		}
+
+		hp = RollHitPoints(classes, -3, WithFirstLevelRolled(), WithMinimumHitPointsPerLevel(2))
+		for _, level := range hp.Levels() {
+			if level.HitPoints() < 2 {
+				t.Errorf("Expected at least 2 hit points, got %s", level)
+			}
+		}
+	}
+}
+
+// TestHitPointsTranscript tests the transcript of the hit points
+func TestHitPointsTranscript(t *testing.T) {
+	classes := []ClassLevel{
+		{Class: "Fighter", Levels: 1, HitDie: D10},
+		{Class: "Rogue", Levels: 1, HitDie: D8},
+	}
+	hp := RollHitPoints(classes, 2, WithHitPointPolicy(HitPointsAverage))
+
+	expected := "Level 1 (Fighter): Max 10 (1d10) +2 = 12\nLevel 2 (Rogue): Average 5 (1d8) +2 = 7\nTotal: 19"
+	if hp.Transcript() != expected {
+		t.Errorf("Expected transcript `%s`, got `%s`", expected, hp.Transcript())
+	}
+	if hp.String() != "19 HP (Fighter 1, Rogue 1)" {
+		t.Errorf("Expected `19 HP (Fighter 1, Rogue 1)`, got `%s`", hp)
+	}
+
+	hp = RollHitPoints(classes, 0)
+	if !strings.HasPrefix(hp.Levels()[1].String(), "Level 2 (Rogue): ") {
+		t.Errorf("Unexpected level string `%s`", hp.Levels()[1])
+	}
+}
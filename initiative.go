@@ -0,0 +1,727 @@
+package dice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TieBreaker determines the order of combatants that rolled the same initiative.
+type TieBreaker int
+
+const (
+	_                   TieBreaker = iota
+	TieBreakDexterity              // The combatant with the higher dexterity modifier goes first
+	TieBreakPlayerFirst            // Players go before other combatants
+	TieBreakReroll                 // Tied combatants roll a D20, with the higher roll going first
+)
+
+var (
+	ErrCombatantExists   = errors.New("combatant already exists")
+	ErrCombatantNotFound = errors.New("combatant not found")
+	ErrNotRolled         = errors.New("initiative has not been rolled")
+	ErrUnsupportedDice   = errors.New("dice cannot be saved")
+)
+
+// Combatant is a participant in combat that acts in initiative order.
+type Combatant interface {
+	Name() string          // The name of the combatant
+	Dice() Dice            // The dice rolled for initiative
+	Roll() Roll            // The initiative roll; nil if initiative was not rolled in this session
+	Initiative() int       // The initiative of the combatant
+	DexModifier() int      // The dexterity modifier used to break ties
+	IsPlayer() bool        // Returns true if the combatant is a player
+	IsDelayed() bool       // Returns true if the combatant has delayed their turn
+	ReadiedAction() string // The trigger for the combatant's readied action, or an empty string if none
+	fmt.Stringer           // String representation of the combatant
+}
+
+// Initiative tracks the order in which combatants act during combat.
+type Initiative interface {
+	Add(name string, d Dice, opts ...CombatantOption) (Combatant, error) // Adds a combatant, rolling initiative if combat has started
+	Remove(name string) error                                            // Removes a combatant from combat
+	Combatant(name string) Combatant                                     // Gets a combatant by name, or nil if it is not found
+	Roll() []Combatant                                                   // Rolls initiative for all combatants and starts the first round
+	Order() []Combatant                                                  // The combatants in the order they act, excluding delayed combatants
+	Current() Combatant                                                  // The combatant whose turn it is, or nil if initiative has not been rolled
+	Next() Combatant                                                     // Advances to the next turn, returning the combatant whose turn it is
+	Round() int                                                          // The current round, starting at 1; 0 if initiative has not been rolled
+	Delay() error                                                        // Delays the current combatant's turn and advances to the next turn
+	Resume(name string) error                                            // Ends a delay, with the combatant acting immediately
+	Ready(trigger string) error                                          // Readies an action for the current combatant and advances to the next turn
+	TriggerReadied(name string) (Combatant, error)                       // Triggers and clears a combatant's readied action
+	json.Marshaler                                                       // Saves the state of the tracker
+	json.Unmarshaler                                                     // Restores the state of the tracker
+	fmt.Stringer                                                         // String representation of the initiative order
+}
+
+// combatant is an implementation of the Combatant interface.
+type combatant struct {
+	name        string   // The name of the combatant
+	dice        Dice     // The dice rolled for initiative
+	rollType    RollType // Type of roll used for initiative (ROLL_ONCE, ROLL_ADVANTAGE, ROLL_DISADVANTATE)
+	roll        Roll     // The initiative roll
+	rollText    string   // The string representation of the initiative roll
+	initiative  int      // The initiative of the combatant
+	tieBreak    int      // The roll used to break ties when using TieBreakReroll
+	dexModifier int      // The dexterity modifier used to break ties
+	isPlayer    bool     // If true, the combatant is a player
+	isDelayed   bool     // If true, the combatant has delayed their turn
+	readied     string   // The trigger for the combatant's readied action
+}
+
+// initiative is an implementation of the Initiative interface.
+type initiative struct {
+	combatants  []*combatant // The combatants, in initiative order once initiative is rolled
+	tieBreakers []TieBreaker // The rules used to break ties, in the order they are applied
+	round       int          // The current round
+	turn        int          // The index of the combatant whose turn it is
+}
+
+// CombatantOption is a function that can modify the default values of a combatant.
+type CombatantOption func(*combatant)
+
+// InitiativeOption is a function that can modify the default values of an initiative tracker.
+type InitiativeOption func(*initiative)
+
+// NewInitiative creates a new initiative tracker. By default, ties are broken by the dexterity
+// modifier, and then by re-rolling.
+func NewInitiative(opts ...InitiativeOption) Initiative {
+	it := &initiative{
+		tieBreakers: []TieBreaker{TieBreakDexterity, TieBreakReroll},
+	}
+	for _, opt := range opts {
+		opt(it)
+	}
+
+	return it
+}
+
+// LoadInitiative restores an initiative tracker that was saved using json.Marshal.
+func LoadInitiative(data []byte) (Initiative, error) {
+	it := &initiative{}
+	if err := json.Unmarshal(data, it); err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// WithTieBreakers sets the rules used to break ties, in the order they are applied. Any remaining
+// ties are left in the order the combatants were added.
+func WithTieBreakers(tieBreakers ...TieBreaker) InitiativeOption {
+	return func(it *initiative) {
+		it.tieBreakers = append([]TieBreaker{}, tieBreakers...)
+	}
+}
+
+// AsPlayer marks the combatant as a player.
+func AsPlayer() CombatantOption {
+	return func(c *combatant) {
+		c.isPlayer = true
+	}
+}
+
+// WithDexModifier sets the dexterity modifier used to break ties. This defaults to the modifier of
+// the initiative dice.
+func WithDexModifier(modifier int) CombatantOption {
+	return func(c *combatant) {
+		c.dexModifier = modifier
+	}
+}
+
+// WithInitiativeOptions sets the options used when rolling initiative for the combatant. Only
+// advantage and disadvantage apply to initiative rolls.
+func WithInitiativeOptions(opts ...RollOption) CombatantOption {
+	return func(c *combatant) {
+		r := &roll{
+			rollType: c.rollType,
+		}
+		for _, opt := range opts {
+			opt(r)
+		}
+		c.rollType = r.rollType
+	}
+}
+
+// Add adds a combatant to the tracker. If initiative has already been rolled, initiative is rolled
+// for the combatant and they are inserted into the order. A combatant whose initiative is higher
+// than the current turn acts in the next round.
+func (it *initiative) Add(name string, d Dice, opts ...CombatantOption) (Combatant, error) {
+	if it.find(name) >= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrCombatantExists, name)
+	}
+
+	c := &combatant{
+		name:        name,
+		dice:        d,
+		rollType:    RollOnce,
+		dexModifier: totalModifier(d),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if it.round == 0 {
+		it.combatants = append(it.combatants, c)
+		return c, nil
+	}
+
+	// Insert the combatant into the order after any combatant that goes before them
+	c.rollInitiative()
+	i := 0
+	for i < len(it.combatants) && it.before(it.combatants[i], c) {
+		i++
+	}
+	it.combatants = append(it.combatants, nil)
+	copy(it.combatants[i+1:], it.combatants[i:])
+	it.combatants[i] = c
+	if i <= it.turn {
+		it.turn++
+	}
+
+	return c, nil
+}
+
+// Remove removes the combatant from the tracker.
+func (it *initiative) Remove(name string) error {
+	i := it.find(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrCombatantNotFound, name)
+	}
+
+	it.combatants = append(it.combatants[:i], it.combatants[i+1:]...)
+	if i < it.turn {
+		it.turn--
+	}
+	if it.round > 0 {
+		it.skipDelayed()
+	}
+	return nil
+}
+
+// Combatant returns the combatant with the name, or nil if it is not found.
+func (it *initiative) Combatant(name string) Combatant {
+	if i := it.find(name); i >= 0 {
+		return it.combatants[i]
+	}
+	return nil
+}
+
+// Roll rolls initiative for all combatants, sorts them into initiative order, and starts the first
+// round.
+func (it *initiative) Roll() []Combatant {
+	for _, c := range it.combatants {
+		c.rollInitiative()
+	}
+	sort.SliceStable(it.combatants, func(i, j int) bool {
+		return it.before(it.combatants[i], it.combatants[j])
+	})
+	it.round = 1
+	it.turn = 0
+
+	return it.Order()
+}
+
+// Order returns the combatants in the order they act, excluding delayed combatants.
+func (it *initiative) Order() []Combatant {
+	order := make([]Combatant, 0, len(it.combatants))
+	for _, c := range it.combatants {
+		if !c.isDelayed {
+			order = append(order, c)
+		}
+	}
+	return order
+}
+
+// Current returns the combatant whose turn it is.
+func (it *initiative) Current() Combatant {
+	if it.round == 0 || it.turn >= len(it.combatants) || it.combatants[it.turn].isDelayed {
+		return nil
+	}
+	return it.combatants[it.turn]
+}
+
+// Next advances to the next combatant's turn, starting a new round after the last combatant. Any
+// readied action held by the combatant expires at the start of their turn.
+func (it *initiative) Next() Combatant {
+	if it.round == 0 || len(it.Order()) == 0 {
+		return nil
+	}
+
+	it.turn++
+	it.skipDelayed()
+	current := it.combatants[it.turn]
+	current.readied = ""
+
+	return current
+}
+
+// Round returns the current round.
+func (it *initiative) Round() int {
+	return it.round
+}
+
+// Delay delays the current combatant's turn, removing them from the order until they resume.
+func (it *initiative) Delay() error {
+	current := it.Current()
+	if current == nil {
+		return ErrNotRolled
+	}
+
+	it.combatants[it.turn].isDelayed = true
+	if len(it.Order()) > 0 {
+		it.turn++
+		it.skipDelayed()
+	}
+	return nil
+}
+
+// Resume ends a combatant's delay. The combatant acts immediately, and their initiative changes to
+// match the combatant who was acting.
+func (it *initiative) Resume(name string) error {
+	i := it.find(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrCombatantNotFound, name)
+	}
+	c := it.combatants[i]
+	if !c.isDelayed {
+		return nil
+	}
+
+	// Move the combatant in front of the combatant whose turn it is
+	c.isDelayed = false
+	it.combatants = append(it.combatants[:i], it.combatants[i+1:]...)
+	if i < it.turn {
+		it.turn--
+	}
+	if len(it.Order()) > 0 {
+		c.initiative = it.combatants[it.turn].initiative
+	}
+	it.combatants = append(it.combatants, nil)
+	copy(it.combatants[it.turn+1:], it.combatants[it.turn:])
+	it.combatants[it.turn] = c
+
+	return nil
+}
+
+// Ready readies an action for the current combatant, to be taken when the trigger occurs, and
+// advances to the next turn.
+func (it *initiative) Ready(trigger string) error {
+	current := it.Current()
+	if current == nil {
+		return ErrNotRolled
+	}
+
+	it.combatants[it.turn].readied = trigger
+	it.Next()
+	return nil
+}
+
+// TriggerReadied triggers the combatant's readied action, clearing it. The combatant's place in the
+// initiative order does not change.
+func (it *initiative) TriggerReadied(name string) (Combatant, error) {
+	i := it.find(name)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrCombatantNotFound, name)
+	}
+	c := it.combatants[i]
+	if c.readied == "" {
+		return nil, fmt.Errorf("%s has no readied action", name)
+	}
+
+	c.readied = ""
+	return c, nil
+}
+
+// find returns the index of the combatant with the name, or -1 if it is not found.
+func (it *initiative) find(name string) int {
+	for i, c := range it.combatants {
+		if c.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// skipDelayed moves the turn forward past any delayed combatants, starting a new round if the end
+// of the order is reached.
+func (it *initiative) skipDelayed() {
+	if len(it.Order()) == 0 {
+		it.turn = 0
+		return
+	}
+	for {
+		if it.turn >= len(it.combatants) {
+			it.turn = 0
+			it.round++
+		}
+		if !it.combatants[it.turn].isDelayed {
+			return
+		}
+		it.turn++
+	}
+}
+
+// before returns true if combatant a acts before combatant b.
+func (it *initiative) before(a, b *combatant) bool {
+	if a.initiative != b.initiative {
+		return a.initiative > b.initiative
+	}
+	for _, tieBreaker := range it.tieBreakers {
+		switch {
+		case tieBreaker == TieBreakDexterity && a.dexModifier != b.dexModifier:
+			return a.dexModifier > b.dexModifier
+		case tieBreaker == TieBreakPlayerFirst && a.isPlayer != b.isPlayer:
+			return a.isPlayer
+		case tieBreaker == TieBreakReroll && a.tieBreak != b.tieBreak:
+			return a.tieBreak > b.tieBreak
+		}
+	}
+	return false
+}
+
+// rollInitiative rolls initiative for the combatant, along with a D20 used to break ties.
+func (c *combatant) rollInitiative() {
+	var opts []RollOption
+	switch c.rollType {
+	case RollWithAdvantage:
+		opts = append(opts, WithAdvantage())
+	case RollWithDisadvantage:
+		opts = append(opts, WithDisadvantage())
+	}
+
+	c.roll = c.dice.Roll(opts...)
+	c.rollText = c.roll.String()
+	c.initiative = c.roll.Value()
+	c.tieBreak = D20.Roll().Value()
+}
+
+// Name returns the name of the combatant.
+func (c *combatant) Name() string {
+	return c.name
+}
+
+// Dice returns the dice rolled for initiative.
+func (c *combatant) Dice() Dice {
+	return c.dice
+}
+
+// Roll returns the initiative roll, or nil if initiative was not rolled in this session.
+func (c *combatant) Roll() Roll {
+	return c.roll
+}
+
+// Initiative returns the initiative of the combatant.
+func (c *combatant) Initiative() int {
+	return c.initiative
+}
+
+// DexModifier returns the dexterity modifier used to break ties.
+func (c *combatant) DexModifier() int {
+	return c.dexModifier
+}
+
+// IsPlayer returns `true` if the combatant is a player; `false` otherwise
+func (c *combatant) IsPlayer() bool {
+	return c.isPlayer
+}
+
+// IsDelayed returns `true` if the combatant has delayed their turn; `false` otherwise
+func (c *combatant) IsDelayed() bool {
+	return c.isDelayed
+}
+
+// ReadiedAction returns the trigger for the combatant's readied action.
+func (c *combatant) ReadiedAction() string {
+	return c.readied
+}
+
+// String returns a string representation of the combatant, such as `Goblin: 14 (1d20+2) = 14`.
+func (c *combatant) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(c.name)
+	sb.WriteString(": ")
+	if c.rollText != "" {
+		sb.WriteString(c.rollText)
+	} else {
+		sb.WriteString(c.dice.String())
+	}
+	if c.isDelayed {
+		sb.WriteString(" (Delayed)")
+	}
+	if c.readied != "" {
+		sb.WriteString(" (Readied: ")
+		sb.WriteString(c.readied)
+		sb.WriteString(")")
+	}
+
+	return sb.String()
+}
+
+// String returns a string representation of the initiative order, marking the current combatant.
+func (it *initiative) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("Round ")
+	sb.WriteString(strconv.Itoa(it.round))
+	for i, c := range it.combatants {
+		sb.WriteString("\n")
+		if it.round > 0 && i == it.turn && !c.isDelayed {
+			sb.WriteString("> ")
+		} else {
+			sb.WriteString("  ")
+		}
+		sb.WriteString(c.String())
+	}
+
+	return sb.String()
+}
+
+// combatantState is the saved state of a combatant.
+type combatantState struct {
+	Name        string    `json:"name"`
+	Dice        diceState `json:"dice"`
+	RollType    RollType  `json:"rollType,omitempty"`
+	Roll        string    `json:"roll,omitempty"`
+	Initiative  int       `json:"initiative"`
+	TieBreak    int       `json:"tieBreak,omitempty"`
+	DexModifier int       `json:"dexModifier"`
+	IsPlayer    bool      `json:"isPlayer,omitempty"`
+	IsDelayed   bool      `json:"isDelayed,omitempty"`
+	Readied     string    `json:"readied,omitempty"`
+}
+
+// initiativeState is the saved state of an initiative tracker.
+type initiativeState struct {
+	Combatants  []combatantState `json:"combatants"`
+	TieBreakers []TieBreaker     `json:"tieBreakers"`
+	Round       int              `json:"round"`
+	Turn        int              `json:"turn"`
+}
+
+// diceState is the saved state of the dice rolled for initiative. Exactly one of Dice, Set and Preset
+// is used.
+type diceState struct {
+	Dice       string      `json:"dice,omitempty"`       // The dice notation of a single dice, such as `1d20+2`; debuffs have a leading `-`
+	Source     string      `json:"source,omitempty"`     // The source of a single dice
+	DamageType DamageType  `json:"damageType,omitempty"` // The damage type of a single dice
+	IsLucky    bool        `json:"isLucky,omitempty"`    // If true, a single dice is lucky
+	ShuffleBag int         `json:"shuffleBag,omitempty"` // The number of copies of each face in the shuffle bag of a single dice
+	Digits     string      `json:"digits,omitempty"`     // The notation of digit dice, such as `d66`
+	Set        []diceState `json:"set,omitempty"`        // The dice in a dice set
+	Preset     *diceState  `json:"preset,omitempty"`     // The dice rolled by preset dice
+	Options    *rollState  `json:"options,omitempty"`    // The roll options of preset dice
+}
+
+// rollState is the saved state of the roll options of preset dice.
+type rollState struct {
+	RollType           RollType           `json:"rollType,omitempty"`
+	CriticalHitAllowed bool               `json:"criticalHitAllowed,omitempty"`
+	CriticalHit        int                `json:"criticalHit,omitempty"`
+	CriticalMiss       int                `json:"criticalMiss,omitempty"`
+	CriticalDamage     CriticalDamageRule `json:"criticalDamage,omitempty"`
+}
+
+// MarshalJSON saves the state of the initiative tracker. An error is returned if the initiative dice
+// of a combatant cannot be saved.
+func (it *initiative) MarshalJSON() ([]byte, error) {
+	state := initiativeState{
+		Combatants:  make([]combatantState, 0, len(it.combatants)),
+		TieBreakers: it.tieBreakers,
+		Round:       it.round,
+		Turn:        it.turn,
+	}
+	for _, c := range it.combatants {
+		ds, err := saveDice(c.dice)
+		if err != nil {
+			return nil, fmt.Errorf("combatant %s: %w", c.name, err)
+		}
+		state.Combatants = append(state.Combatants, combatantState{
+			Name:        c.name,
+			Dice:        ds,
+			RollType:    c.rollType,
+			Roll:        c.rollText,
+			Initiative:  c.initiative,
+			TieBreak:    c.tieBreak,
+			DexModifier: c.dexModifier,
+			IsPlayer:    c.isPlayer,
+			IsDelayed:   c.isDelayed,
+			Readied:     c.readied,
+		})
+	}
+
+	return json.Marshal(state)
+}
+
+// UnmarshalJSON restores the state of an initiative tracker.
+func (it *initiative) UnmarshalJSON(data []byte) error {
+	var state initiativeState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	if state.Turn < 0 || (state.Turn > 0 && state.Turn >= len(state.Combatants)) {
+		return fmt.Errorf("turn %d is out of range", state.Turn)
+	}
+
+	it.combatants = make([]*combatant, 0, len(state.Combatants))
+	it.tieBreakers = state.TieBreakers
+	it.round = state.Round
+	it.turn = state.Turn
+	for _, cs := range state.Combatants {
+		d, err := loadDice(cs.Dice)
+		if err != nil {
+			return fmt.Errorf("combatant %s: %w", cs.Name, err)
+		}
+		it.combatants = append(it.combatants, &combatant{
+			name:        cs.Name,
+			dice:        d,
+			rollType:    cs.RollType,
+			rollText:    cs.Roll,
+			initiative:  cs.Initiative,
+			tieBreak:    cs.TieBreak,
+			dexModifier: cs.DexModifier,
+			isPlayer:    cs.IsPlayer,
+			isDelayed:   cs.IsDelayed,
+			readied:     cs.Readied,
+		})
+	}
+
+	return nil
+}
+
+// saveDice returns the saved state of the dice. An error is returned if the dice, or any of the dice
+// it is made up of, is of a type that cannot be saved.
+func saveDice(d Dice) (diceState, error) {
+	switch d := d.(type) {
+	case *dice:
+		ds := diceState{
+			Dice:       getDiceString(d.numDice, d.numSides, d.modifier),
+			Source:     d.source,
+			DamageType: d.damageType,
+			IsLucky:    d.isLucky,
+		}
+		if d.isDebuff {
+			ds.Dice = "-" + ds.Dice
+		}
+		if d.shuffleBag != nil {
+			ds.ShuffleBag = d.shuffleBag.copies
+		}
+		return ds, nil
+	case *digitDice:
+		return diceState{
+			Digits: d.notation(),
+			Source: d.source,
+		}, nil
+	case diceSet:
+		ds := diceState{
+			Set: make([]diceState, 0, len(d)),
+		}
+		for _, die := range d {
+			saved, err := saveDice(die)
+			if err != nil {
+				return diceState{}, err
+			}
+			ds.Set = append(ds.Set, saved)
+		}
+		return ds, nil
+	case *presetDice:
+		saved, err := saveDice(d.Dice)
+		if err != nil {
+			return diceState{}, err
+		}
+		r := &roll{}
+		for _, opt := range d.opts {
+			opt(r)
+		}
+		return diceState{
+			Preset: &saved,
+			Options: &rollState{
+				RollType:           r.rollType,
+				CriticalHitAllowed: r.criticalHitAllowed,
+				CriticalHit:        r.criticalHit,
+				CriticalMiss:       r.criticalMiss,
+				CriticalDamage:     r.criticalDamage,
+			},
+		}, nil
+	default:
+		return diceState{}, fmt.Errorf("%w: %T", ErrUnsupportedDice, d)
+	}
+}
+
+// loadDice returns the dice for the state created by saveDice. An error is returned if the state does
+// not describe any dice.
+func loadDice(ds diceState) (Dice, error) {
+	switch {
+	case ds.Preset != nil:
+		d, err := loadDice(*ds.Preset)
+		if err != nil {
+			return nil, err
+		}
+		var opts []RollOption
+		if ds.Options != nil {
+			opts = ds.Options.rollOptions()
+		}
+		return NewPresetDice(d, opts...), nil
+	case ds.Set != nil:
+		dice := make([]Dice, 0, len(ds.Set))
+		for _, saved := range ds.Set {
+			d, err := loadDice(saved)
+			if err != nil {
+				return nil, err
+			}
+			dice = append(dice, d)
+		}
+		return NewDiceSet(dice...), nil
+	case ds.Digits != "":
+		var opts []DiceOption
+		if ds.Source != "" {
+			opts = append(opts, WithSource(ds.Source))
+		}
+		return ParseDigitDice(ds.Digits, opts...)
+	case ds.Dice != "":
+		var opts []DiceOption
+		if ds.Source != "" {
+			opts = append(opts, WithSource(ds.Source))
+		}
+		if ds.DamageType != "" {
+			opts = append(opts, WithDamageType(ds.DamageType))
+		}
+		if ds.IsLucky {
+			opts = append(opts, WithLuck())
+		}
+		if ds.ShuffleBag > 0 {
+			opts = append(opts, WithShuffleBag(ds.ShuffleBag))
+		}
+		return ParseDice(ds.Dice, opts...), nil
+	default:
+		return nil, fmt.Errorf("%w: no dice were saved", ErrUnsupportedDice)
+	}
+}
+
+// rollOptions returns the roll options that recreate the saved options.
+func (rs *rollState) rollOptions() []RollOption {
+	var opts []RollOption
+	switch rs.RollType {
+	case RollWithAdvantage:
+		opts = append(opts, WithAdvantage())
+	case RollWithDisadvantage:
+		opts = append(opts, WithDisadvantage())
+	}
+	if rs.CriticalHitAllowed {
+		opts = append(opts, WithCriticalHitAllowed())
+	}
+	if rs.CriticalHit != 0 {
+		opts = append(opts, WithCriticalHit(rs.CriticalHit))
+	}
+	if rs.CriticalMiss != 0 {
+		opts = append(opts, WithCriticalMiss(rs.CriticalMiss))
+	}
+	if rs.CriticalDamage != 0 {
+		opts = append(opts, WithCriticalDamage(rs.CriticalDamage))
+	}
+	return opts
+}
@@ -0,0 +1,317 @@
+package dice
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// names returns the names of the combatants
+func names(combatants []Combatant) string {
+	names := make([]string, 0, len(combatants))
+	for _, c := range combatants {
+		names = append(names, c.Name())
+	}
+	return strings.Join(names, ",")
+}
+
+// TestInitiativeOrder tests rolling initiative and ordering the combatants
+func TestInitiativeOrder(t *testing.T) {
+	it := NewInitiative()
+	it.Add("Fighter", NewConstant(12), AsPlayer())
+	it.Add("Goblin", NewConstant(15))
+	it.Add("Wizard", NewConstant(8), AsPlayer())
+
+	if it.Current() != nil || it.Round() != 0 {
+		t.Errorf("Expected no current combatant before initiative is rolled")
+	}
+
+	order := it.Roll()
+	if names(order) != "Goblin,Fighter,Wizard" {
+		t.Errorf("Expected order Goblin,Fighter,Wizard, got %s", names(order))
+	}
+	if it.Round() != 1 || it.Current().Name() != "Goblin" {
+		t.Errorf("Expected Goblin to act first in round 1, got %s in round %d", it.Current(), it.Round())
+	}
+
+	if it.Next().Name() != "Fighter" || it.Next().Name() != "Wizard" {
+		t.Errorf("Expected Fighter and then Wizard to act")
+	}
+	if it.Next().Name() != "Goblin" || it.Round() != 2 {
+		t.Errorf("Expected Goblin to act in round 2, got %s in round %d", it.Current(), it.Round())
+	}
+
+	if _, err := it.Add("Goblin", NewConstant(1)); !errors.Is(err, ErrCombatantExists) {
+		t.Errorf("Expected ErrCombatantExists, got %v", err)
+	}
+}
+
+// TestInitiativeTieBreakers tests breaking ties between combatants
+func TestInitiativeTieBreakers(t *testing.T) {
+	it := NewInitiative()
+	it.Add("Orc", NewConstant(14), WithDexModifier(1))
+	it.Add("Rogue", NewConstant(14), WithDexModifier(4), AsPlayer())
+	if names(it.Roll()) != "Rogue,Orc" {
+		t.Errorf("Expected higher dexterity to go first, got %s", names(it.Order()))
+	}
+
+	it = NewInitiative(WithTieBreakers(TieBreakPlayerFirst))
+	it.Add("Orc", NewConstant(14), WithDexModifier(4))
+	it.Add("Cleric", NewConstant(14), WithDexModifier(0), AsPlayer())
+	if names(it.Roll()) != "Cleric,Orc" {
+		t.Errorf("Expected player to go first, got %s", names(it.Order()))
+	}
+
+	// The dexterity modifier defaults to the modifier of the dice
+	c, _ := it.Add("Ranger", ParseDice("1d20+3"))
+	if c.DexModifier() != 3 {
+		t.Errorf("Expected dexterity modifier of 3, got %d", c.DexModifier())
+	}
+
+	for i := 0; i < 20; i++ {
+		it = NewInitiative(WithTieBreakers(TieBreakReroll))
+		it.Add("A", NewConstant(10))
+		it.Add("B", NewConstant(10))
+		order := it.Roll()
+		first, second := order[0].(*combatant), order[1].(*combatant)
+		if first.tieBreak < second.tieBreak {
+			t.Errorf("Expected higher tie-break roll to go first, got %d and %d", first.tieBreak, second.tieBreak)
+		}
+	}
+}
+
+// TestInitiativeRollOptions tests rolling initiative with advantage
+func TestInitiativeRollOptions(t *testing.T) {
+	it := NewInitiative()
+	it.Add("Barbarian", ParseDice("1d20+2"), WithInitiativeOptions(WithAdvantage()))
+	it.Add("Bard", ParseDice("1d20+1"))
+	it.Roll()
+
+	if !it.Combatant("Barbarian").Roll().RolledWithAdvantage() {
+		t.Errorf("Expected Barbarian to roll with advantage")
+	}
+	if it.Combatant("Bard").Roll().RolledWithAdvantage() {
+		t.Errorf("Expected Bard to roll without advantage")
+	}
+	if it.Combatant("Paladin") != nil {
+		t.Errorf("Expected missing combatant to be nil")
+	}
+}
+
+// TestInitiativeAddMidRound tests adding combatants after initiative is rolled
+func TestInitiativeAddMidRound(t *testing.T) {
+	it := NewInitiative()
+	it.Add("A", NewConstant(20))
+	it.Add("B", NewConstant(10))
+	it.Add("C", NewConstant(5))
+	it.Roll()
+	it.Next() // B's turn
+
+	// A combatant with a higher initiative acts in the next round
+	it.Add("D", NewConstant(15))
+	if it.Current().Name() != "B" {
+		t.Errorf("Expected B to still be acting, got %s", it.Current())
+	}
+	// A combatant with a lower initiative acts this round
+	it.Add("E", NewConstant(7))
+	if names(it.Order()) != "A,D,B,E,C" {
+		t.Errorf("Expected order A,D,B,E,C, got %s", names(it.Order()))
+	}
+	if it.Next().Name() != "E" || it.Next().Name() != "C" || it.Next().Name() != "A" || it.Round() != 2 {
+		t.Errorf("Expected E, C and then A in round 2")
+	}
+
+	it.Remove("A")
+	if it.Current().Name() != "D" {
+		t.Errorf("Expected D to act after A is removed, got %s", it.Current())
+	}
+	if err := it.Remove("A"); !errors.Is(err, ErrCombatantNotFound) {
+		t.Errorf("Expected ErrCombatantNotFound, got %v", err)
+	}
+}
+
+// TestInitiativeDelay tests delaying a turn
+func TestInitiativeDelay(t *testing.T) {
+	it := NewInitiative()
+	it.Add("A", NewConstant(20))
+	it.Add("B", NewConstant(10))
+	it.Add("C", NewConstant(5))
+
+	if err := it.Delay(); !errors.Is(err, ErrNotRolled) {
+		t.Errorf("Expected ErrNotRolled, got %v", err)
+	}
+
+	it.Roll()
+	it.Delay()
+	if it.Current().Name() != "B" || !it.Combatant("A").IsDelayed() {
+		t.Errorf("Expected B to act after A delays, got %s", it.Current())
+	}
+	if names(it.Order()) != "B,C" {
+		t.Errorf("Expected delayed combatant to be removed from the order, got %s", names(it.Order()))
+	}
+
+	it.Next() // C's turn
+	it.Resume("A")
+	if it.Current().Name() != "A" || it.Combatant("A").Initiative() != 5 {
+		t.Errorf("Expected A to act with initiative 5, got %s", it.Current())
+	}
+	if it.Next().Name() != "C" || it.Next().Name() != "B" || it.Round() != 2 {
+		t.Errorf("Expected C and then B in round 2")
+	}
+}
+
+// TestInitiativeReady tests readying an action
+func TestInitiativeReady(t *testing.T) {
+	it := NewInitiative()
+	it.Add("A", NewConstant(20))
+	it.Add("B", NewConstant(10))
+	it.Roll()
+
+	it.Ready("the door opens")
+	if it.Current().Name() != "B" || it.Combatant("A").ReadiedAction() != "the door opens" {
+		t.Errorf("Expected A to ready an action, got %s", it)
+	}
+
+	c, err := it.TriggerReadied("A")
+	if err != nil || c.Name() != "A" || c.ReadiedAction() != "" {
+		t.Errorf("Expected A's readied action to be triggered, got %v", err)
+	}
+	if _, err := it.TriggerReadied("A"); err == nil {
+		t.Errorf("Expected an error when there is no readied action")
+	}
+
+	// A readied action expires at the start of the combatant's next turn
+	it.Ready("an enemy approaches")
+	it.Next()
+	if it.Combatant("B").ReadiedAction() != "" {
+		t.Errorf("Expected readied action to expire, got %s", it.Combatant("B"))
+	}
+}
+
+// TestInitiativeSerialization tests saving and restoring an initiative tracker
+func TestInitiativeSerialization(t *testing.T) {
+	it := NewInitiative(WithTieBreakers(TieBreakPlayerFirst))
+	it.Add("Fighter", ParseDice("1d20+2"), AsPlayer(), WithInitiativeOptions(WithAdvantage()))
+	it.Add("Goblins", NewDiceSet(ParseDice("1d20+1"), ParseDice("1d4", AsDebuff())))
+	it.Add("Wizard", ParseDice("1d20-1"), AsPlayer())
+	it.Roll()
+	it.Next()
+	it.Delay()
+
+	data, err := json.Marshal(it)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	restored, err := LoadInitiative(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if restored.String() != it.String() {
+		t.Errorf("Expected restored tracker to be\n%s\ngot\n%s", it, restored)
+	}
+	if restored.Round() != it.Round() || restored.Current().Name() != it.Current().Name() {
+		t.Errorf("Expected restored tracker to be at the same turn")
+	}
+	if restored.Combatant("Goblins").Dice().String() != "1d20+1 - 1d4" {
+		t.Errorf("Expected restored dice to be `1d20+1 - 1d4`, got `%s`", restored.Combatant("Goblins").Dice())
+	}
+
+	// The restored tracker continues from where it was saved
+	if restored.Next() == nil {
+		t.Errorf("Expected restored tracker to advance")
+	}
+
+	if _, err := LoadInitiative([]byte(`{"combatants": [], "turn": 3}`)); err == nil {
+		t.Errorf("Expected an error for an invalid turn")
+	}
+}
+
+// customDice is a dice type that is not known to the package, for testing
+type customDice struct {
+	Dice
+}
+
+// TestInitiativeSerializationDiceOptions tests that the options of the initiative dice are saved
+func TestInitiativeSerializationDiceOptions(t *testing.T) {
+	it := NewInitiative()
+	it.Add("Halfling", ParseDice("1d20+3", WithLuck(), WithSource("Lucky")))
+	it.Add("Rogue", NewPresetDice(ParseDice("1d20+4"), WithAdvantage(), WithCriticalHit(19)))
+
+	data, err := json.Marshal(it)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	restored, err := LoadInitiative(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	halfling := restored.Combatant("Halfling").Dice()
+	if !halfling.IsLucky() || halfling.Source() != "Lucky" || halfling.Modifier() != 3 {
+		t.Errorf("Expected a lucky 1d20+3 from Lucky, got %s", halfling.Str())
+	}
+	if !equalDistributions(diceDistribution(halfling), diceDistribution(ParseDice("1d20+3", WithLuck()))) {
+		t.Errorf("Expected the restored dice to roll the same values as a lucky 1d20+3")
+	}
+
+	rogue := restored.Combatant("Rogue").Dice()
+	if _, ok := rogue.(*presetDice); !ok {
+		t.Fatalf("Expected preset dice, got %T", rogue)
+	}
+	if r := rogue.Roll(); !r.RolledWithAdvantage() {
+		t.Errorf("Expected the restored dice to roll with advantage, got %s", r)
+	}
+	if !equalDistributions(diceDistribution(rogue), diceDistribution(NewPresetDice(ParseDice("1d20+4"), WithAdvantage()))) {
+		t.Errorf("Expected the restored dice to roll the same values as 1d20+4 with advantage")
+	}
+
+	it.Add("Stranger", customDice{D20})
+	if _, err := json.Marshal(it); !errors.Is(err, ErrUnsupportedDice) {
+		t.Errorf("Expected ErrUnsupportedDice, got %v", err)
+	}
+}
+
+// TestInitiativeSerializationDigitDice tests saving and loading a combatant that rolls digit dice
+func TestInitiativeSerializationDigitDice(t *testing.T) {
+	d36, _ := ParseDigitDice("d36", WithSource("Rumors"))
+	it := NewInitiative()
+	it.Add("Traveller", D66)
+	it.Add("Scout", d36)
+
+	data, err := json.Marshal(it)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Contains(string(data), "2d36") {
+		t.Errorf("Expected d66 to be saved as digit dice, got %s", data)
+	}
+	restored, err := LoadInitiative(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for name, expected := range map[string]Dice{"Traveller": D66, "Scout": d36} {
+		d := restored.Combatant(name).Dice()
+		if _, ok := d.(*digitDice); !ok || d.Str() != expected.Str() {
+			t.Errorf("Expected %s to roll %s, got %s", name, expected.Str(), d.Str())
+		}
+		if !equalDistributions(diceDistribution(d), diceDistribution(expected)) {
+			t.Errorf("Expected %s to roll the same values as %s", name, expected.Str())
+		}
+	}
+}
+
+// equalDistributions returns true if the distributions have the same probability for every value
+func equalDistributions(a, b map[int]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for value, p := range a {
+		if !almostEqual(p, b[value]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,216 @@
+package dice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ActionOutcome is the result of an Ironsworn action roll.
+type ActionOutcome int
+
+const (
+	_                ActionOutcome = iota
+	OutcomeMiss                    // The action score beat neither challenge die
+	OutcomeWeakHit                 // The action score beat one of the challenge dice
+	OutcomeStrongHit               // The action score beat both of the challenge dice
+)
+
+const (
+	MaxActionScore = 10 // The action score is capped at 10, regardless of the stat and adds
+)
+
+// ActionRoll is an Ironsworn action roll. A D6 action die is added to a stat and any adds to produce
+// the action score, which is compared against two D10 challenge dice.
+type ActionRoll interface {
+	ActionDie() Roll                            // The roll of the action die
+	ChallengeDice() []Roll                      // The rolls of the two challenge dice
+	Stat() int                                  // The stat added to the action die
+	Adds() int                                  // Any additional bonuses added to the action die
+	Score() int                                 // The action score compared against the challenge dice
+	Outcome() ActionOutcome                     // The outcome of the roll (strong hit, weak hit or miss)
+	IsMatch() bool                              // Returns true if both challenge dice rolled the same value
+	MomentumBurned() bool                       // Returns true if momentum was burned to replace the action score
+	BurnMomentum(momentum int) ActionRoll       // Replaces the action score with momentum, returning a new ActionRoll
+	ReRoll(opts ...ActionRollOption) ActionRoll // Re-rolls the action with the same stat, returning a new ActionRoll
+	fmt.Stringer                                // String representation of the roll, including the outcome
+	Str() string                                // String representation of the roll, but without the outcome
+}
+
+// actionRoll is an implementation of the ActionRoll interface.
+type actionRoll struct {
+	stat           int     // The stat added to the action die
+	adds           int     // Any additional bonuses added to the action die
+	momentum       int     // The current momentum; negative momentum may cancel the action die
+	actionDie      Roll    // The roll of the action die
+	challengeDice  [2]Roll // The rolls of the challenge dice
+	score          int     // The action score
+	momentumBurned bool    // If true, the action score was replaced by momentum
+}
+
+// ActionRollOption is a function that can modify the default values of an action roll.
+type ActionRollOption func(*actionRoll)
+
+// RollAction makes an Ironsworn action roll for the given stat. The action die is a D6 and the
+// challenge dice are two D10s.
+func RollAction(stat int, opts ...ActionRollOption) ActionRoll {
+	ar := &actionRoll{
+		stat: stat,
+	}
+	for _, opt := range opts {
+		opt(ar)
+	}
+
+	ar.actionDie = D6.Roll()
+	ar.challengeDice = [2]Roll{D10.Roll(), D10.Roll()}
+	ar.score = ar.actionScore()
+
+	return ar
+}
+
+// WithAdds sets the adds that are included in the action score.
+func WithAdds(adds int) ActionRollOption {
+	return func(ar *actionRoll) {
+		ar.adds = adds
+	}
+}
+
+// WithMomentum sets the current momentum for the action roll. If momentum is negative and its
+// absolute value matches the action die, the action die is cancelled and does not count towards
+// the action score.
+func WithMomentum(momentum int) ActionRollOption {
+	return func(ar *actionRoll) {
+		ar.momentum = momentum
+	}
+}
+
+// actionScore returns the action score for the roll, capped at MaxActionScore.
+func (ar *actionRoll) actionScore() int {
+	actionDie := ar.actionDie.Value()
+	if ar.momentum < 0 && -ar.momentum == actionDie {
+		// Negative momentum cancels the action die
+		actionDie = 0
+	}
+	return min(actionDie+ar.stat+ar.adds, MaxActionScore)
+}
+
+// ActionDie returns the roll of the action die.
+func (ar *actionRoll) ActionDie() Roll {
+	return ar.actionDie
+}
+
+// ChallengeDice returns the rolls of the two challenge dice.
+func (ar *actionRoll) ChallengeDice() []Roll {
+	return []Roll{ar.challengeDice[0], ar.challengeDice[1]}
+}
+
+// Stat returns the stat added to the action die.
+func (ar *actionRoll) Stat() int {
+	return ar.stat
+}
+
+// Adds returns the adds included in the action score.
+func (ar *actionRoll) Adds() int {
+	return ar.adds
+}
+
+// Score returns the action score, or the momentum if momentum was burned.
+func (ar *actionRoll) Score() int {
+	return ar.score
+}
+
+// Outcome returns the outcome of the action roll.
+func (ar *actionRoll) Outcome() ActionOutcome {
+	return actionOutcome(ar.score, ar.challengeDice[0].Value(), ar.challengeDice[1].Value())
+}
+
+// IsMatch returns `true` if both challenge dice rolled the same value; `false` otherwise
+func (ar *actionRoll) IsMatch() bool {
+	return ar.challengeDice[0].Value() == ar.challengeDice[1].Value()
+}
+
+// MomentumBurned returns `true` if momentum was burned for the roll; `false` otherwise
+func (ar *actionRoll) MomentumBurned() bool {
+	return ar.momentumBurned
+}
+
+// BurnMomentum replaces the action score with the provided momentum and recomputes the outcome
+// against the same challenge dice. The original roll is not modified.
+func (ar *actionRoll) BurnMomentum(momentum int) ActionRoll {
+	burned := *ar
+	burned.score = momentum
+	burned.momentum = 0
+	burned.momentumBurned = true
+	return &burned
+}
+
+// ReRoll makes a new action roll using the same stat, adds and momentum as this roll, with any
+// provided options applied.
+func (ar *actionRoll) ReRoll(opts ...ActionRollOption) ActionRoll {
+	newOpts := make([]ActionRollOption, 0, len(opts)+2)
+	newOpts = append(newOpts, WithAdds(ar.adds), WithMomentum(ar.momentum))
+	newOpts = append(newOpts, opts...)
+	return RollAction(ar.stat, newOpts...)
+}
+
+// actionOutcome returns the outcome of an action score compared against two challenge dice. The
+// action score must beat a challenge die; ties go to the challenge die.
+func actionOutcome(score int, challenge1 int, challenge2 int) ActionOutcome {
+	switch {
+	case score > challenge1 && score > challenge2:
+		return OutcomeStrongHit
+	case score > challenge1 || score > challenge2:
+		return OutcomeWeakHit
+	default:
+		return OutcomeMiss
+	}
+}
+
+// String returns a string representation of the outcome.
+func (o ActionOutcome) String() string {
+	switch o {
+	case OutcomeStrongHit:
+		return "Strong Hit"
+	case OutcomeWeakHit:
+		return "Weak Hit"
+	case OutcomeMiss:
+		return "Miss"
+	default:
+		return "Unknown"
+	}
+}
+
+// String returns a string representation of the action roll, including the outcome and whether
+// the challenge dice were a match.
+func (ar *actionRoll) String() string {
+	var sb strings.Builder
+	sb.WriteString(ar.Str())
+	sb.WriteString(" = ")
+	sb.WriteString(ar.Outcome().String())
+	if ar.IsMatch() {
+		sb.WriteString(" (Match)")
+	}
+
+	return sb.String()
+}
+
+// Str returns a string representation of the action roll, but without the outcome.
+func (ar *actionRoll) Str() string {
+	var sb strings.Builder
+
+	sb.WriteString(strconv.Itoa(ar.score))
+	sb.WriteString(" (")
+	if ar.momentumBurned {
+		sb.WriteString("Momentum")
+	} else {
+		sb.WriteString(getDiceString(1, 6, ar.stat+ar.adds))
+		sb.WriteString(", ")
+		sb.WriteString(strconv.Itoa(ar.actionDie.Value()))
+	}
+	sb.WriteString(") vs ")
+	sb.WriteString(strconv.Itoa(ar.challengeDice[0].Value()))
+	sb.WriteString(", ")
+	sb.WriteString(strconv.Itoa(ar.challengeDice[1].Value()))
+
+	return sb.String()
+}
@@ -0,0 +1,163 @@
+package dice
+
+import (
+	"strings"
+	"testing"
+)
+
+// newTestActionRoll creates an action roll with fixed dice values
+func newTestActionRoll(stat, adds, momentum, actionDie, challenge1, challenge2 int) *actionRoll {
+	ar := &actionRoll{
+		stat:      stat,
+		adds:      adds,
+		momentum:  momentum,
+		actionDie: &singleRoll{value: actionDie, dice: D6},
+		challengeDice: [2]Roll{
+			&singleRoll{value: challenge1, dice: D10},
+			&singleRoll{value: challenge2, dice: D10},
+		},
+	}
+	ar.score = ar.actionScore()
+	return ar
+}
+
+// TestActionOutcome tests the outcome of an action score against the challenge dice
+func TestActionOutcome(t *testing.T) {
+	tests := []struct {
+		score      int
+		challenge1 int
+		challenge2 int
+		expected   ActionOutcome
+	}{
+		{8, 3, 7, OutcomeStrongHit},
+		{7, 3, 7, OutcomeWeakHit},
+		{5, 9, 4, OutcomeWeakHit},
+		{4, 4, 4, OutcomeMiss},
+		{2, 9, 10, OutcomeMiss},
+		{10, 10, 10, OutcomeMiss},
+	}
+
+	for _, test := range tests {
+		outcome := actionOutcome(test.score, test.challenge1, test.challenge2)
+		if outcome != test.expected {
+			t.Errorf("actionOutcome(%d, %d, %d) = %s; expected %s",
+				test.score, test.challenge1, test.challenge2, outcome, test.expected)
+		}
+	}
+}
+
+// TestActionScore tests the calculation of the action score
+func TestActionScore(t *testing.T) {
+	ar := newTestActionRoll(2, 1, 0, 4, 1, 1)
+	if ar.Score() != 7 {
+		t.Errorf("Expected action score to be 7, got %d", ar.Score())
+	}
+
+	// The action score is capped at 10
+	ar = newTestActionRoll(4, 3, 0, 6, 1, 1)
+	if ar.Score() != MaxActionScore {
+		t.Errorf("Expected action score to be capped at %d, got %d", MaxActionScore, ar.Score())
+	}
+
+	// Negative momentum that matches the action die cancels it
+	ar = newTestActionRoll(2, 0, -3, 3, 1, 1)
+	if ar.Score() != 2 {
+		t.Errorf("Expected cancelled action die to give a score of 2, got %d", ar.Score())
+	}
+
+	// Negative momentum that does not match the action die has no effect
+	ar = newTestActionRoll(2, 0, -3, 4, 1, 1)
+	if ar.Score() != 6 {
+		t.Errorf("Expected action score to be 6, got %d", ar.Score())
+	}
+}
+
+// TestActionRollMatch tests detecting a match on the challenge dice
+func TestActionRollMatch(t *testing.T) {
+	ar := newTestActionRoll(3, 0, 0, 5, 4, 4)
+	if !ar.IsMatch() {
+		t.Errorf("Expected challenge dice 4 and 4 to be a match")
+	}
+	if ar.Outcome() != OutcomeStrongHit {
+		t.Errorf("Expected outcome to be a strong hit, got %s", ar.Outcome())
+	}
+
+	ar = newTestActionRoll(3, 0, 0, 5, 4, 5)
+	if ar.IsMatch() {
+		t.Errorf("Expected challenge dice 4 and 5 to not be a match")
+	}
+}
+
+// TestBurnMomentum tests replacing the action score with momentum
+func TestBurnMomentum(t *testing.T) {
+	ar := newTestActionRoll(2, 0, 0, 1, 5, 8)
+	if ar.Outcome() != OutcomeMiss {
+		t.Fatalf("Expected outcome to be a miss, got %s", ar.Outcome())
+	}
+
+	burned := ar.BurnMomentum(9)
+	if burned.Score() != 9 {
+		t.Errorf("Expected burned score to be 9, got %d", burned.Score())
+	}
+	if burned.Outcome() != OutcomeStrongHit {
+		t.Errorf("Expected burned outcome to be a strong hit, got %s", burned.Outcome())
+	}
+	if !burned.MomentumBurned() {
+		t.Errorf("Expected momentum to be burned")
+	}
+
+	// The original roll is unchanged
+	if ar.Score() != 3 || ar.MomentumBurned() {
+		t.Errorf("Expected original roll to be unchanged, got score %d", ar.Score())
+	}
+
+	// The challenge dice are the same as the original roll
+	if burned.ChallengeDice()[0].Value() != 5 || burned.ChallengeDice()[1].Value() != 8 {
+		t.Errorf("Expected challenge dice to be unchanged, got %v", burned.ChallengeDice())
+	}
+}
+
+// TestRollAction tests making an action roll
+func TestRollAction(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		ar := RollAction(2, WithAdds(1))
+
+		actionDie := ar.ActionDie().Value()
+		if actionDie < 1 || actionDie > 6 {
+			t.Errorf("Expected action die between 1 and 6, got %d", actionDie)
+		}
+		for _, c := range ar.ChallengeDice() {
+			if c.Value() < 1 || c.Value() > 10 {
+				t.Errorf("Expected challenge die between 1 and 10, got %d", c.Value())
+			}
+		}
+		if ar.Score() != actionDie+3 {
+			t.Errorf("Expected action score to be %d, got %d", actionDie+3, ar.Score())
+		}
+		if ar.Stat() != 2 || ar.Adds() != 1 {
+			t.Errorf("Expected stat 2 and adds 1, got %d and %d", ar.Stat(), ar.Adds())
+		}
+
+		reroll := ar.ReRoll()
+		if reroll.Stat() != 2 || reroll.Adds() != 1 {
+			t.Errorf("Expected re-roll to keep stat 2 and adds 1, got %d and %d", reroll.Stat(), reroll.Adds())
+		}
+	}
+}
+
+// TestActionRollString tests the string representation of an action roll
+func TestActionRollString(t *testing.T) {
+	ar := newTestActionRoll(2, 1, 0, 5, 3, 3)
+	expected := "8 (1d6+3, 5) vs 3, 3 = Strong Hit (Match)"
+	if ar.String() != expected {
+		t.Errorf("Expected `%s`, got `%s`", expected, ar.String())
+	}
+
+	burned := ar.BurnMomentum(2)
+	if !strings.Contains(burned.String(), "Momentum") {
+		t.Errorf("Expected burned roll to mention momentum, got `%s`", burned.String())
+	}
+	if !strings.HasSuffix(burned.String(), "Miss (Match)") {
+		t.Errorf("Expected burned roll to be a miss, got `%s`", burned.String())
+	}
+}
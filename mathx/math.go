@@ -7,6 +7,12 @@ type Number interface {
 		~float32 | ~float64
 }
 
+// Integer is a type constraint that matches all integer types.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
 // Abs returns the absolute value of a number.
 func Abs[T Number](x T) T {
 	return AbsDiff(x, 0)
@@ -19,3 +25,11 @@ func AbsDiff[T Number](x, y T) T {
 	}
 	return x - y
 }
+
+// GCD returns the greatest common divisor of two integers, which is always positive unless both are zero.
+func GCD[T Integer](x, y T) T {
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return Abs(x)
+}
@@ -83,3 +83,25 @@ func TestAbsDiffFloat(t *testing.T) {
 		}
 	}
 }
+
+func TestGCD(t *testing.T) {
+	tests := []struct {
+		x, y     int
+		expected int
+	}{
+		{12, 18, 6},
+		{18, 12, 6},
+		{7, 5, 1},
+		{0, 4, 4},
+		{4, 0, 4},
+		{0, 0, 0},
+		{-12, 8, 4},
+	}
+
+	for _, test := range tests {
+		result := GCD(test.x, test.y)
+		if result != test.expected {
+			t.Errorf("gcd(%d, %d) = %d; expected %d", test.x, test.y, result, test.expected)
+		}
+	}
+}
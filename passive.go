@@ -0,0 +1,245 @@
+package dice
+
+import (
+	"strconv"
+	"strings"
+)
+
+// fixedRollType is the rule used to produce a roll without rolling the dice.
+type fixedRollType int
+
+const (
+	_            fixedRollType = iota
+	fixedPassive               // A passive check, using 10 for the d20
+	fixedTake10                // Taking 10, using 10 for the d20
+	fixedTake20                // Taking 20, using 20 for the d20
+	fixedMaximum               // The maximum value of the dice
+	fixedAverage               // The average value of the dice, rounded up
+)
+
+const (
+	PassiveBase         = 10 // The value used for the d20 in a passive check or when taking 10
+	PassiveAdvantage    = 5  // The bonus to a passive check made with advantage
+	PassiveDisadvantage = -5 // The penalty to a passive check made with disadvantage
+	Take20Base          = 20 // The value used for the d20 when taking 20
+)
+
+// fixedRoll is an implementation of the Roll interface for checks that are made without rolling
+// the dice.
+type fixedRoll struct {
+	fixedType fixedRollType // The rule used to produce the roll
+	rollType  RollType      // Type of roll (ROLL_ONCE, ROLL_ADVANTAGE, ROLL_DISADVANTATE)
+	value     int           // The value of the roll
+	dice      Dice          // The dice used for the roll
+}
+
+// Passive returns the passive score for the dice as a Roll. Each d20 counts as 10, other dice count
+// as their average rounded down, and modifiers are added as normal. A passive check with advantage
+// gains +5, and one with disadvantage suffers -5.
+func Passive(d Dice, opts ...RollOption) Roll {
+	return newFixedRoll(fixedPassive, d, opts...)
+}
+
+// Take10 returns the result of taking 10 on the dice as a Roll. Each d20 counts as 10, other dice
+// count as their average rounded down, and modifiers are added as normal.
+func Take10(d Dice) Roll {
+	return newFixedRoll(fixedTake10, d)
+}
+
+// Take20 returns the result of taking 20 on the dice as a Roll. Each d20 counts as 20, other dice
+// count as their average rounded down, and modifiers are added as normal.
+func Take20(d Dice) Roll {
+	return newFixedRoll(fixedTake20, d)
+}
+
+// newFixedRoll creates a roll for the dice using the fixed roll type. Only advantage and disadvantage
+// affect the roll, and only for passive checks. The options of preset dice are applied before the
+// provided options, as they are when the dice is rolled.
+func newFixedRoll(fixedType fixedRollType, d Dice, opts ...RollOption) *fixedRoll {
+	rollOpts := opts
+	if pd, ok := d.(*presetDice); ok {
+		rollOpts = pd.rollOptions(opts)
+	}
+	r := &roll{
+		rollType: RollOnce,
+	}
+	for _, opt := range rollOpts {
+		opt(r)
+	}
+
+	fr := &fixedRoll{
+		fixedType: fixedType,
+		rollType:  RollOnce,
+		dice:      d,
+	}
+
+	d20Value := PassiveBase
+	if fixedType == fixedTake20 {
+		d20Value = Take20Base
+	}
+	for _, die := range d.GetDice() {
+		switch fixedType {
+		case fixedMaximum:
+			fr.value += maximumDiceValue(die)
+		case fixedAverage:
+			fr.value += averageDiceValue(die)
+		default:
+			fr.value += fixedDiceValue(die, d20Value)
+		}
+	}
+
+	if fixedType == fixedPassive {
+		fr.rollType = r.rollType
+		switch r.rollType {
+		case RollWithAdvantage:
+			fr.value += PassiveAdvantage
+		case RollWithDisadvantage:
+			fr.value += PassiveDisadvantage
+		}
+	}
+
+	return fr
+}
+
+// fixedDiceValue returns the value of the dice without rolling it. Each d20 counts as the provided
+// value, and other dice count as their average rounded down.
+func fixedDiceValue(d Dice, d20Value int) int {
+	if dd, ok := d.(*digitDice); ok {
+		values := dd.values()
+		return sumValues(values) / len(values)
+	}
+	value := d.Modifier()
+	if d.NumSides() == 20 {
+		value += d.NumDice() * d20Value
+	} else if d.NumSides() > 0 {
+		value += d.NumDice() * (d.NumSides() + 1) / 2
+	}
+	if d.IsDebuff() {
+		value = -value
+	}
+	return value
+}
+
+// maximumDiceValue returns the highest value that can be rolled on the dice.
+func maximumDiceValue(d Dice) int {
+	if dd, ok := d.(*digitDice); ok {
+		values := dd.values()
+		return values[len(values)-1]
+	}
+	value := d.NumDice()*d.NumSides() + d.Modifier()
+	if d.IsDebuff() {
+		value = -value
+	}
+	return value
+}
+
+// averageDiceValue returns the average value of the dice, rounded up.
+func averageDiceValue(d Dice) int {
+	if dd, ok := d.(*digitDice); ok {
+		values := dd.values()
+		return (sumValues(values) + len(values) - 1) / len(values)
+	}
+	value := d.Modifier() + (d.NumDice()*(d.NumSides()+1)+1)/2
+	if d.NumSides() <= 0 {
+		value = d.Modifier()
+	}
+	if d.IsDebuff() {
+		value = -value
+	}
+	return value
+}
+
+// sumValues returns the sum of the values.
+func sumValues(values []int) int {
+	sum := 0
+	for _, value := range values {
+		sum += value
+	}
+	return sum
+}
+
+// GetAllRolls returns a slice containing this roll, as the dice is not rolled.
+func (r *fixedRoll) GetAllRolls() []Roll {
+	return []Roll{r}
+}
+
+// Value gets the value of the roll, including the modifiers.
+func (r *fixedRoll) Value() int {
+	return r.value
+}
+
+// IsCriticalHit always returns `false`, as a roll that is not rolled cannot be a critical hit.
+func (r *fixedRoll) IsCriticalHit() bool {
+	return false
+}
+
+// IsCriticalMiss always returns `false`, as a roll that is not rolled cannot be a critical miss.
+func (r *fixedRoll) IsCriticalMiss() bool {
+	return false
+}
+
+// RolledWithAdvantage returns `true` if a passive check was made with advantage; `false` otherwise
+func (r *fixedRoll) RolledWithAdvantage() bool {
+	return r.rollType == RollWithAdvantage
+}
+
+// RolledWithDisadvantage returns `true` if a passive check was made with disadvantage; `false` otherwise
+func (r *fixedRoll) RolledWithDisadvantage() bool {
+	return r.rollType == RollWithDisadvantage
+}
+
+// ReRoll returns a new roll using the same rule as this roll, with the provided options applied.
+func (r *fixedRoll) ReRoll(opts ...RollOption) Roll {
+	return newFixedRoll(r.fixedType, r.dice, opts...)
+}
+
+// GetType gets the type of roll (ROLL_ONCE, ROLL_WITH_ADVANTAGE, ROLL_WITH_DISADVANTATE)
+func (r *fixedRoll) GetType() RollType {
+	return r.rollType
+}
+
+// GetDice gets the dice that was used for this roll.
+func (r *fixedRoll) GetDice() Dice {
+	return r.dice
+}
+
+// Check checks if the roll meets or exceeds the value.
+func (r *fixedRoll) Check(v Value) bool {
+	return r.Value() >= v.Value()
+}
+
+// String returns a string representation of the roll, such as `Passive 15 (1d20+5)`.
+func (r *fixedRoll) String() string {
+	return r.Str()
+}
+
+// Str returns a string representation of the roll. This includes the rule used for the roll, its
+// value, and the dice that would have been rolled.
+func (r *fixedRoll) Str() string {
+	var sb strings.Builder
+
+	switch r.fixedType {
+	case fixedPassive:
+		sb.WriteString("Passive ")
+	case fixedTake10:
+		sb.WriteString("Take 10 ")
+	case fixedTake20:
+		sb.WriteString("Take 20 ")
+	case fixedMaximum:
+		sb.WriteString("Max ")
+	case fixedAverage:
+		sb.WriteString("Average ")
+	}
+	sb.WriteString(strconv.Itoa(r.value))
+	sb.WriteString(" (")
+	sb.WriteString(r.dice.String())
+	switch {
+	case r.RolledWithAdvantage():
+		sb.WriteString(", Advantage")
+	case r.RolledWithDisadvantage():
+		sb.WriteString(", Disadvantage")
+	}
+	sb.WriteString(")")
+
+	return sb.String()
+}
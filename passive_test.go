@@ -0,0 +1,87 @@
+package dice
+
+import "testing"
+
+// TestPassive tests calculating a passive score for a dice
+func TestPassive(t *testing.T) {
+	tests := []struct {
+		dice     Dice
+		opts     []RollOption
+		expected int
+		str      string
+	}{
+		{ParseDice("1d20+5"), nil, 15, "Passive 15 (1d20+5)"},
+		{ParseDice("1d20+5"), []RollOption{WithAdvantage()}, 20, "Passive 20 (1d20+5, Advantage)"},
+		{ParseDice("1d20-1"), []RollOption{WithDisadvantage()}, 4, "Passive 4 (1d20-1, Disadvantage)"},
+		{ParseDice("1d20+2"), []RollOption{WithAdvantage(), WithDisadvantage()}, 12, "Passive 12 (1d20+2)"},
+		{D20, nil, 10, "Passive 10 (1d20)"},
+		{NewDiceSet(ParseDice("1d20+3"), ParseDice("1d4")), nil, 15, "Passive 15 (1d20+3 + 1d4)"},
+		{NewPresetDice(ParseDice("1d20+3"), WithAdvantage()), nil, 18, "Passive 18 (1d20+3, Advantage)"},
+		{NewPresetDice(ParseDice("1d20+3"), WithAdvantage()), []RollOption{WithDisadvantage()}, 13, "Passive 13 (1d20+3)"},
+	}
+
+	for _, test := range tests {
+		r := Passive(test.dice, test.opts...)
+		if r.Value() != test.expected {
+			t.Errorf("Passive(%s).Value() = %d; expected %d", test.dice, r.Value(), test.expected)
+		}
+		if r.String() != test.str {
+			t.Errorf("Passive(%s).String() = `%s`; expected `%s`", test.dice, r.String(), test.str)
+		}
+		if r.IsCriticalHit() || r.IsCriticalMiss() {
+			t.Errorf("Passive(%s) should never be a critical hit or miss", test.dice)
+		}
+	}
+}
+
+// TestTake10AndTake20 tests taking 10 and taking 20 on a dice
+func TestTake10AndTake20(t *testing.T) {
+	d := ParseDice("1d20+4")
+
+	r := Take10(d)
+	if r.Value() != 14 {
+		t.Errorf("Take10(%s).Value() = %d; expected 14", d, r.Value())
+	}
+	if r.String() != "Take 10 14 (1d20+4)" {
+		t.Errorf("Take10(%s).String() = `%s`; expected `Take 10 14 (1d20+4)`", d, r.String())
+	}
+
+	r = Take20(d)
+	if r.Value() != 24 {
+		t.Errorf("Take20(%s).Value() = %d; expected 24", d, r.Value())
+	}
+	if r.String() != "Take 20 24 (1d20+4)" {
+		t.Errorf("Take20(%s).String() = `%s`; expected `Take 20 24 (1d20+4)`", d, r.String())
+	}
+
+	// Advantage does not apply when taking 20
+	r = r.ReRoll(WithAdvantage())
+	if r.Value() != 24 || r.RolledWithAdvantage() {
+		t.Errorf("Expected re-rolled Take20 to ignore advantage, got %s", r)
+	}
+}
+
+// TestPassiveCheck tests using a passive score in a check against a difficulty class
+func TestPassiveCheck(t *testing.T) {
+	r := Passive(ParseDice("1d20+3"))
+
+	if !NewDifficultyClass(13).Check(r) {
+		t.Errorf("Expected passive 13 to pass DC 13")
+	}
+	if NewDifficultyClass(14).Check(r) {
+		t.Errorf("Expected passive 13 to fail DC 14")
+	}
+	if !r.Check(NewDifficultyClass(13)) {
+		t.Errorf("Expected passive 13 to meet DC 13")
+	}
+
+	// The passive score can be compared against a rolled value
+	if Passive(ParseDice("1d20+3")).Check(NewConstant(14).Roll()) {
+		t.Errorf("Expected passive 13 to not meet a roll of 14")
+	}
+
+	r = r.ReRoll(WithAdvantage())
+	if r.Value() != 18 || !r.RolledWithAdvantage() || r.GetType() != RollWithAdvantage {
+		t.Errorf("Expected re-rolled passive with advantage to be 18, got %s", r)
+	}
+}
@@ -0,0 +1,56 @@
+package dice
+
+// presetDice is a dice that is always rolled with a set of roll options.
+type presetDice struct {
+	Dice              // The dice being rolled
+	opts []RollOption // The options applied each time the dice is rolled
+}
+
+// NewPresetDice returns a dice that is always rolled with the provided options, such as rolling
+// with advantage. Any options passed to Roll are applied after the preset options.
+func NewPresetDice(d Dice, opts ...RollOption) Dice {
+	preset := make([]RollOption, 0, len(opts))
+	preset = append(preset, opts...)
+	return &presetDice{
+		Dice: d,
+		opts: preset,
+	}
+}
+
+// Roll rolls the dice with the preset options, followed by the provided options.
+func (pd *presetDice) Roll(opts ...RollOption) Roll {
+	return pd.Dice.Roll(pd.rollOptions(opts)...)
+}
+
+// rollOptions returns the preset options followed by the provided options.
+func (pd *presetDice) rollOptions(opts []RollOption) []RollOption {
+	allOpts := make([]RollOption, 0, len(pd.opts)+len(opts))
+	allOpts = append(allOpts, pd.opts...)
+	allOpts = append(allOpts, opts...)
+	return allOpts
+}
+
+// distribution returns the exact distribution of the values returned by rolling the dice with the
+// preset options applied, followed by the roll type and critical damage rule.
+func (pd *presetDice) distribution(rollType RollType, rule CriticalDamageRule) distribution {
+	r := &roll{
+		rollType: RollOnce,
+	}
+	for _, opt := range pd.opts {
+		opt(r)
+	}
+
+	// Apply the roll type after the preset options, as is done by Roll
+	switch rollType {
+	case RollWithAdvantage:
+		WithAdvantage()(r)
+	case RollWithDisadvantage:
+		WithDisadvantage()(r)
+	}
+
+	if rule != 0 {
+		WithCriticalDamage(rule)(r)
+	}
+
+	return distributionOf(pd.Dice, r.rollType, r.criticalDamage)
+}
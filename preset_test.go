@@ -0,0 +1,41 @@
+package dice
+
+import "testing"
+
+// TestPresetDice tests rolling a dice with preset roll options
+func TestPresetDice(t *testing.T) {
+	d := NewPresetDice(NewDice(1, 20, WithModifier(3)), WithAdvantage())
+
+	if d.String() != "1d20+3" {
+		t.Errorf("Expected preset dice to be `1d20+3`, got `%s`", d.String())
+	}
+
+	r := d.Roll()
+	if !r.RolledWithAdvantage() {
+		t.Errorf("Expected preset dice to roll with advantage")
+	}
+	if r.Value() < 4 || r.Value() > 23 {
+		t.Errorf("Expected roll between 4 and 23, got %d", r.Value())
+	}
+
+	// Options passed to Roll are applied after the preset options
+	r = d.Roll(WithDisadvantage())
+	if r.RolledWithAdvantage() || r.RolledWithDisadvantage() {
+		t.Errorf("Expected advantage and disadvantage to cancel out")
+	}
+}
+
+// TestPresetDiceDistribution tests the distribution of a dice with preset roll options
+func TestPresetDiceDistribution(t *testing.T) {
+	d := NewPresetDice(D20, WithAdvantage())
+
+	dist := diceDistribution(d)
+	if !almostEqual(dist[20], 39.0/400.0) {
+		t.Errorf("Expected P(20) with advantage to be %f, got %f", 39.0/400.0, dist[20])
+	}
+
+	dist = diceDistribution(d, WithDisadvantage())
+	if !almostEqual(dist[20], 1.0/20.0) {
+		t.Errorf("Expected P(20) with advantage and disadvantage to be %f, got %f", 1.0/20.0, dist[20])
+	}
+}
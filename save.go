@@ -0,0 +1,239 @@
+package dice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SaveVariant determines the damage a target takes depending on whether it made its saving throw.
+type SaveVariant int
+
+const (
+	_           SaveVariant = iota
+	SaveHalf                // Half damage on a successful save, full damage on a failed save
+	SaveEvasion             // No damage on a successful save, half damage on a failed save
+	SaveNegates             // No damage on a successful save, full damage on a failed save
+)
+
+// SaveOutcome is the outcome of a saving throw made by a single target.
+type SaveOutcome interface {
+	Save() Roll           // The saving throw rolled by the target
+	Saved() bool          // Returns true if the target made its saving throw
+	Variant() SaveVariant // The variant used to determine the damage taken by the target
+	Damage() int          // The damage taken by the target
+	fmt.Stringer          // String representation of the outcome
+}
+
+// SaveResult is the result of damage that is applied to many targets, each making a saving throw.
+type SaveResult interface {
+	DamageRoll() Roll                 // The damage roll, which is rolled once for all targets
+	DifficultyClass() DifficultyClass // The difficulty class for the saving throws
+	Outcomes() []SaveOutcome          // The outcome for each target, in the same order as the targets
+	fmt.Stringer                      // String representation of the result
+}
+
+// saveOutcome is an implementation of the SaveOutcome interface.
+type saveOutcome struct {
+	save    Roll        // The saving throw rolled by the target
+	saved   bool        // If true, the target made its saving throw
+	variant SaveVariant // The variant used to determine the damage taken
+	damage  int         // The damage taken by the target
+}
+
+// saveResult is an implementation of the SaveResult interface.
+type saveResult struct {
+	damage   Roll            // The damage roll
+	dc       DifficultyClass // The difficulty class for the saving throws
+	outcomes []*saveOutcome  // The outcome for each target
+}
+
+// saveConfig holds the options used when resolving damage with saving throws.
+type saveConfig struct {
+	variant        SaveVariant         // The variant used for targets without their own variant
+	targetVariants map[int]SaveVariant // The variants for individual targets, by index
+	damageOpts     []RollOption        // The options used when rolling the damage
+	saveOpts       []RollOption        // The options used when rolling each saving throw
+	rounding       Rounding            // The rounding used when damage is halved
+}
+
+// SaveOption is a function that can modify the default values used when resolving saving throws.
+type SaveOption func(*saveConfig)
+
+// SaveForHalf rolls the damage once and a saving throw for each target against the difficulty class,
+// returning the damage taken by each target. By default, targets that make their save take half
+// damage, rounded down, and those that fail take full damage. Critical hits and misses on a saving
+// throw are honored if the saves are rolled with WithCriticalHitAllowed; as with attacks, a d20 with a
+// modifier is rolled as a d20 and a constant so that a natural 20 or 1 is detected.
+func SaveForHalf(damage Dice, dc DifficultyClass, targets []Dice, opts ...SaveOption) SaveResult {
+	cfg := &saveConfig{
+		variant:        SaveHalf,
+		targetVariants: make(map[int]SaveVariant),
+		rounding:       RoundDown,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	sr := &saveResult{
+		damage:   damage.Roll(cfg.damageOpts...),
+		dc:       dc,
+		outcomes: make([]*saveOutcome, 0, len(targets)),
+	}
+	rolled := max(sr.damage.Value(), 0)
+	for i, target := range targets {
+		variant, ok := cfg.targetVariants[i]
+		if !ok {
+			variant = cfg.variant
+		}
+
+		so := &saveOutcome{
+			save:    attackToHitDice(target).Roll(cfg.saveOpts...),
+			variant: variant,
+		}
+		so.saved = dc.Check(so.save)
+		so.damage = saveDamage(rolled, so.saved, variant, cfg.rounding)
+		sr.outcomes = append(sr.outcomes, so)
+	}
+
+	return sr
+}
+
+// WithSaveVariant sets the variant used for all targets that do not have their own variant.
+func WithSaveVariant(variant SaveVariant) SaveOption {
+	return func(cfg *saveConfig) {
+		cfg.variant = variant
+	}
+}
+
+// WithTargetVariant sets the variant used for a single target, such as a target with evasion. The
+// target is identified by its index in the list of targets.
+func WithTargetVariant(target int, variant SaveVariant) SaveOption {
+	return func(cfg *saveConfig) {
+		cfg.targetVariants[target] = variant
+	}
+}
+
+// WithDamageRollOptions sets the options used when rolling the damage, such as WithCriticalDamage.
+func WithDamageRollOptions(opts ...RollOption) SaveOption {
+	return func(cfg *saveConfig) {
+		cfg.damageOpts = append(cfg.damageOpts, opts...)
+	}
+}
+
+// WithSaveRollOptions sets the options used when rolling each saving throw, such as
+// WithCriticalHitAllowed or WithAdvantage.
+func WithSaveRollOptions(opts ...RollOption) SaveOption {
+	return func(cfg *saveConfig) {
+		cfg.saveOpts = append(cfg.saveOpts, opts...)
+	}
+}
+
+// WithSaveRounding sets the rounding used when damage is halved.
+func WithSaveRounding(rounding Rounding) SaveOption {
+	return func(cfg *saveConfig) {
+		cfg.rounding = rounding
+	}
+}
+
+// saveDamage returns the damage taken by a target, depending on whether it made its save.
+func saveDamage(damage int, saved bool, variant SaveVariant, rounding Rounding) int {
+	switch {
+	case variant == SaveEvasion && saved, variant == SaveNegates && saved:
+		return 0
+	case variant == SaveEvasion, saved:
+		return HalveDamage(damage, rounding)
+	default:
+		return damage
+	}
+}
+
+// DamageRoll returns the damage roll.
+func (sr *saveResult) DamageRoll() Roll {
+	return sr.damage
+}
+
+// DifficultyClass returns the difficulty class for the saving throws.
+func (sr *saveResult) DifficultyClass() DifficultyClass {
+	return sr.dc
+}
+
+// Outcomes returns the outcome for each target.
+func (sr *saveResult) Outcomes() []SaveOutcome {
+	outcomes := make([]SaveOutcome, 0, len(sr.outcomes))
+	for _, so := range sr.outcomes {
+		outcomes = append(outcomes, so)
+	}
+	return outcomes
+}
+
+// Save returns the saving throw rolled by the target.
+func (so *saveOutcome) Save() Roll {
+	return so.save
+}
+
+// Saved returns `true` if the target made its saving throw; `false` otherwise
+func (so *saveOutcome) Saved() bool {
+	return so.saved
+}
+
+// Variant returns the variant used to determine the damage taken by the target.
+func (so *saveOutcome) Variant() SaveVariant {
+	return so.variant
+}
+
+// Damage returns the damage taken by the target.
+func (so *saveOutcome) Damage() int {
+	return so.damage
+}
+
+// String returns a string representation of the save variant.
+func (v SaveVariant) String() string {
+	switch v {
+	case SaveHalf:
+		return "Half"
+	case SaveEvasion:
+		return "Evasion"
+	case SaveNegates:
+		return "Negates"
+	default:
+		return "Unknown"
+	}
+}
+
+// String returns a string representation of the outcome, such as `8 (1d20+2) = 8 (Failed): 14 damage`.
+func (so *saveOutcome) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(so.save.String())
+	if so.saved {
+		sb.WriteString(" (Saved")
+	} else {
+		sb.WriteString(" (Failed")
+	}
+	if so.variant != SaveHalf {
+		sb.WriteString(", ")
+		sb.WriteString(so.variant.String())
+	}
+	sb.WriteString("): ")
+	sb.WriteString(strconv.Itoa(so.damage))
+	sb.WriteString(" damage")
+
+	return sb.String()
+}
+
+// String returns a string representation of the result, with the damage roll followed by the outcome
+// for each target on a separate line.
+func (sr *saveResult) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(sr.damage.String())
+	sb.WriteString(" vs DC ")
+	sb.WriteString(sr.dc.String())
+	for _, so := range sr.outcomes {
+		sb.WriteString("\n")
+		sb.WriteString(so.String())
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,131 @@
+package dice
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSaveDamage tests the damage taken for each save variant
+func TestSaveDamage(t *testing.T) {
+	tests := []struct {
+		variant  SaveVariant
+		saved    bool
+		expected int
+	}{
+		{SaveHalf, true, 13},
+		{SaveHalf, false, 27},
+		{SaveEvasion, true, 0},
+		{SaveEvasion, false, 13},
+		{SaveNegates, true, 0},
+		{SaveNegates, false, 27},
+	}
+
+	for _, test := range tests {
+		if damage := saveDamage(27, test.saved, test.variant, RoundDown); damage != test.expected {
+			t.Errorf("saveDamage(27, %v, %s) = %d; expected %d", test.saved, test.variant, damage, test.expected)
+		}
+	}
+
+	if damage := saveDamage(27, true, SaveHalf, RoundUp); damage != 14 {
+		t.Errorf("Expected half damage rounded up to be 14, got %d", damage)
+	}
+}
+
+// TestSaveForHalf tests rolling damage once for many targets
+func TestSaveForHalf(t *testing.T) {
+	targets := []Dice{
+		NewConstant(16),
+		NewConstant(9),
+		NewConstant(18),
+		NewConstant(4),
+	}
+
+	for i := 0; i < 20; i++ {
+		result := SaveForHalf(ParseDice("8d6"), NewDifficultyClass(15), targets,
+			WithTargetVariant(2, SaveEvasion), WithTargetVariant(3, SaveEvasion))
+		damage := result.DamageRoll().Value()
+		if damage < 8 || damage > 48 {
+			t.Errorf("Expected damage between 8 and 48, got %d", damage)
+		}
+
+		outcomes := result.Outcomes()
+		if len(outcomes) != 4 {
+			t.Fatalf("Expected 4 outcomes, got %d", len(outcomes))
+		}
+		expected := []int{damage / 2, damage, 0, damage / 2}
+		for j, outcome := range outcomes {
+			if outcome.Damage() != expected[j] {
+				t.Errorf("Expected target %d to take %d damage, got %s", j, expected[j], outcome)
+			}
+		}
+		if !outcomes[0].Saved() || outcomes[1].Saved() || outcomes[2].Variant() != SaveEvasion {
+			t.Errorf("Unexpected outcomes: %s", result)
+		}
+	}
+
+	result := SaveForHalf(NewConstant(20), NewDifficultyClass(12), targets, WithSaveVariant(SaveNegates))
+	for j, expected := range []int{0, 20, 0, 20} {
+		if result.Outcomes()[j].Damage() != expected {
+			t.Errorf("Expected target %d to take %d damage, got %s", j, expected, result.Outcomes()[j])
+		}
+	}
+}
+
+// TestSaveForHalfCritical tests critical hits and misses on saving throws and critical damage
+func TestSaveForHalfCritical(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		result := SaveForHalf(ParseDice("2d6"), NewDifficultyClass(30), []Dice{D20},
+			WithSaveRollOptions(WithCriticalHitAllowed()),
+			WithDamageRollOptions(WithCriticalDamage(CriticalTimesTwo)))
+		if result.DamageRoll().Value()%2 != 0 {
+			t.Errorf("Expected damage to be doubled, got %s", result.DamageRoll())
+		}
+		outcome := result.Outcomes()[0]
+		if outcome.Saved() != outcome.Save().IsCriticalHit() {
+			t.Errorf("Expected only a natural 20 to save against DC 30, got %s", outcome)
+		}
+	}
+}
+
+// TestSaveForHalfCriticalModifier tests that a saving throw with a modifier can be a critical hit or miss
+func TestSaveForHalfCriticalModifier(t *testing.T) {
+	var hits, misses int
+	for i := 0; i < 2000; i++ {
+		result := SaveForHalf(ParseDice("2d6"), NewDifficultyClass(30), []Dice{ParseDice("1d20+3")},
+			WithSaveRollOptions(WithCriticalHitAllowed()))
+		outcome := result.Outcomes()[0]
+		if outcome.Saved() != outcome.Save().IsCriticalHit() {
+			t.Errorf("Expected only a natural 20 to save against DC 30, got %s", outcome)
+		}
+		if outcome.Save().IsCriticalHit() {
+			hits++
+		}
+		if outcome.Save().IsCriticalMiss() {
+			misses++
+		}
+	}
+	if hits == 0 || misses == 0 {
+		t.Errorf("Expected critical hits and misses on 1d20+3, got %d and %d", hits, misses)
+	}
+}
+
+// TestSaveForHalfString tests the string representation of the result
+func TestSaveForHalfString(t *testing.T) {
+	result := SaveForHalf(NewConstant(10, WithDamageType(Fire)), NewDifficultyClass(12),
+		[]Dice{NewConstant(14), NewConstant(3)}, WithTargetVariant(1, SaveEvasion))
+
+	lines := strings.Split(result.String(), "\n")
+	expected := []string{
+		"10 (fire) = 10 vs DC 12",
+		"14 = 14 (Saved): 5 damage",
+		"3 = 3 (Failed, Evasion): 5 damage",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, got `%s`", len(expected), result)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("Expected `%s`, got `%s`", expected[i], lines[i])
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CantripLevels are the character levels at which cantrip damage increases.
+var CantripLevels = []int{5, 11, 17}
+
+var (
+	ErrInvalidScaling = errors.New("invalid scaling")
+)
+
+// diceExpressionPattern matches a single dice expression, such as `8d6`, `d4+1` or `3`.
+var diceExpressionPattern = regexp.MustCompile(`^[+-]?(\d*d\d+([+-]\d+)?|\d+)$`)
+
+// Scaling is damage that increases with the spell slot or character level it is used at, such as
+// `8d6` at 3rd level plus `1d6` for each slot above, or cantrips that increase at levels 5, 11 and 17.
+type Scaling interface {
+	Base() Dice          // The dice used at the base level, or below it
+	Step() Dice          // The dice added for each step the damage increases
+	Steps(level int) int // The number of steps the damage increases at the level
+	Dice(level int) Dice // The dice used at the level
+	fmt.Stringer         // String representation of the scaling, such as `8d6 +1d6/slot>3`
+}
+
+// scalingUnit identifies whether a scaling increases with the spell slot or character level.
+type scalingUnit string
+
+const (
+	scalingSlot  scalingUnit = "slot"  // The damage increases with the spell slot
+	scalingLevel scalingUnit = "level" // The damage increases with the character level
+)
+
+// scaling is an implementation of the Scaling interface.
+type scaling struct {
+	base       Dice        // The dice used at the base level
+	step       Dice        // The dice added for each step
+	unit       scalingUnit // The unit the damage increases with, if increasing each level above the base
+	baseLevel  int         // The level above which the damage increases each level
+	thresholds []int       // The levels at which the damage increases, if not increasing each level
+}
+
+// NewSlotScaling creates a scaling that adds the step dice for each spell slot above the base slot.
+func NewSlotScaling(base Dice, step Dice, baseSlot int) Scaling {
+	return &scaling{
+		base:      base,
+		step:      step,
+		unit:      scalingSlot,
+		baseLevel: baseSlot,
+	}
+}
+
+// NewLevelScaling creates a scaling that adds the step dice for each character level above the base level.
+func NewLevelScaling(base Dice, step Dice, baseLevel int) Scaling {
+	return &scaling{
+		base:      base,
+		step:      step,
+		unit:      scalingLevel,
+		baseLevel: baseLevel,
+	}
+}
+
+// NewThresholdScaling creates a scaling that adds the step dice at each of the levels, such as
+// CantripLevels.
+func NewThresholdScaling(base Dice, step Dice, levels ...int) Scaling {
+	thresholds := make([]int, 0, len(levels))
+	thresholds = append(thresholds, levels...)
+	return &scaling{
+		base:       base,
+		step:       step,
+		thresholds: thresholds,
+	}
+}
+
+// ParseScaling parses a string representation of a scaling. The supported formats are
+// `8d6 +1d6/slot>3`, which adds 1d6 for each slot above 3rd, `2d8 +1d8/level>1`, which adds
+// 1d8 for each character level above 1st, and `1d10 +1d10@5,11,17`, which adds 1d10 at each of
+// levels 5, 11 and 17. The options are applied to both the base and step dice.
+func ParseScaling(str string, opts ...DiceOption) (Scaling, error) {
+	fields := strings.Fields(str)
+	if len(fields) != 2 || !strings.HasPrefix(fields[1], "+") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidScaling, str)
+	}
+	baseStr := fields[0]
+	stepStr, rule, found := strings.Cut(fields[1][1:], "@")
+	if !found {
+		stepStr, rule, found = strings.Cut(fields[1][1:], "/")
+	}
+	if !found || !diceExpressionPattern.MatchString(baseStr) || !diceExpressionPattern.MatchString(stepStr) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidScaling, str)
+	}
+	base := ParseDice(baseStr, opts...)
+	step := ParseDice(stepStr, opts...)
+
+	if strings.Contains(fields[1], "@") {
+		levels := make([]int, 0, 3)
+		for _, levelStr := range strings.Split(rule, ",") {
+			level, err := strconv.Atoi(strings.TrimSpace(levelStr))
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid level %q", ErrInvalidScaling, levelStr)
+			}
+			levels = append(levels, level)
+		}
+		return NewThresholdScaling(base, step, levels...), nil
+	}
+
+	unit, levelStr, found := strings.Cut(rule, ">")
+	if !found {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidScaling, str)
+	}
+	baseLevel, err := strconv.Atoi(levelStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid level %q", ErrInvalidScaling, levelStr)
+	}
+	switch scalingUnit(unit) {
+	case scalingSlot:
+		return NewSlotScaling(base, step, baseLevel), nil
+	case scalingLevel:
+		return NewLevelScaling(base, step, baseLevel), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown unit %q", ErrInvalidScaling, unit)
+	}
+}
+
+// Base returns the dice used at the base level.
+func (s *scaling) Base() Dice {
+	return s.base
+}
+
+// Step returns the dice added for each step the damage increases.
+func (s *scaling) Step() Dice {
+	return s.step
+}
+
+// Steps returns the number of steps the damage increases at the level.
+func (s *scaling) Steps(level int) int {
+	if s.unit != "" {
+		return max(level-s.baseLevel, 0)
+	}
+
+	var steps int
+	for _, threshold := range s.thresholds {
+		if level >= threshold {
+			steps++
+		}
+	}
+	return steps
+}
+
+// Dice returns the dice used at the level. If the base and step dice have the same number of sides,
+// the result is a single dice, such as `10d6` for `8d6 +1d6/slot>3` at 5th level. Otherwise, the
+// result is a dice set containing the base and step dice.
+func (s *scaling) Dice(level int) Dice {
+	steps := s.Steps(level)
+	if steps == 0 {
+		return s.base
+	}
+
+	base, baseOK := s.base.(*dice)
+	step, stepOK := s.step.(*dice)
+	switch {
+	case baseOK && stepOK && base.isDebuff == step.isDebuff && (base.numSides == step.numSides || step.IsConstant()):
+		d := base.Customize(WithSource(base.source)).(*dice)
+		d.numDice += step.numDice * steps
+		d.modifier += step.modifier * steps
+		return d
+	case stepOK:
+		d := step.Customize(WithSource(step.source)).(*dice)
+		d.numDice *= steps
+		d.modifier *= steps
+		return NewDiceSet(s.base, d)
+	default:
+		ds := make([]Dice, 0, steps+1)
+		ds = append(ds, s.base)
+		for range steps {
+			ds = append(ds, s.step)
+		}
+		return NewDiceSet(ds...)
+	}
+}
+
+// String returns a string representation of the scaling, such as `8d6 +1d6/slot>3`.
+func (s *scaling) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(getDiceString(s.base.NumDice(), s.base.NumSides(), s.base.Modifier()))
+	sb.WriteString(" +")
+	sb.WriteString(getDiceString(s.step.NumDice(), s.step.NumSides(), s.step.Modifier()))
+	if s.unit != "" {
+		sb.WriteString("/")
+		sb.WriteString(string(s.unit))
+		sb.WriteString(">")
+		sb.WriteString(strconv.Itoa(s.baseLevel))
+		return sb.String()
+	}
+
+	sb.WriteString("@")
+	for i, threshold := range s.thresholds {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(strconv.Itoa(threshold))
+	}
+
+	return sb.String()
+}
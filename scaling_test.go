@@ -0,0 +1,129 @@
+package dice
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestSlotScaling tests damage that increases with the spell slot
+func TestSlotScaling(t *testing.T) {
+	s := NewSlotScaling(ParseDice("8d6"), ParseDice("1d6"), 3)
+
+	tests := []struct {
+		slot     int
+		expected string
+	}{
+		{1, "8d6"},
+		{3, "8d6"},
+		{4, "9d6"},
+		{9, "14d6"},
+	}
+	for _, test := range tests {
+		if d := s.Dice(test.slot); d.String() != test.expected {
+			t.Errorf("Expected `%s` at slot %d, got `%s`", test.expected, test.slot, d)
+		}
+	}
+
+	// Dice with different sides are combined into a dice set
+	s = NewSlotScaling(ParseDice("3d8+2"), ParseDice("1d6"), 2)
+	if d := s.Dice(4); d.String() != "3d8+2 + 2d6" {
+		t.Errorf("Expected `3d8+2 + 2d6`, got `%s`", d)
+	}
+
+	// A constant step adds to the modifier
+	s = NewSlotScaling(ParseDice("1d4+1"), NewConstant(1), 1)
+	if d := s.Dice(3); d.String() != "1d4+3" {
+		t.Errorf("Expected `1d4+3`, got `%s`", d)
+	}
+}
+
+// TestThresholdScaling tests damage that increases at character levels
+func TestThresholdScaling(t *testing.T) {
+	s := NewThresholdScaling(ParseDice("1d10"), ParseDice("1d10"), CantripLevels...)
+
+	tests := []struct {
+		level    int
+		steps    int
+		expected string
+	}{
+		{1, 0, "1d10"},
+		{5, 1, "2d10"},
+		{10, 1, "2d10"},
+		{11, 2, "3d10"},
+		{20, 3, "4d10"},
+	}
+	for _, test := range tests {
+		if steps := s.Steps(test.level); steps != test.steps {
+			t.Errorf("Expected %d steps at level %d, got %d", test.steps, test.level, steps)
+		}
+		if d := s.Dice(test.level); d.String() != test.expected {
+			t.Errorf("Expected `%s` at level %d, got `%s`", test.expected, test.level, d)
+		}
+	}
+}
+
+// TestLevelScaling tests damage that increases with each character level
+func TestLevelScaling(t *testing.T) {
+	s := NewLevelScaling(ParseDice("2d8"), ParseDice("1d8"), 1)
+	if d := s.Dice(1); d.String() != "2d8" {
+		t.Errorf("Expected `2d8` at level 1, got `%s`", d)
+	}
+	if d := s.Dice(3); d.String() != "4d8" {
+		t.Errorf("Expected `4d8` at level 3, got `%s`", d)
+	}
+}
+
+// TestParseScaling tests parsing scaling from a string
+func TestParseScaling(t *testing.T) {
+	tests := []struct {
+		str      string
+		level    int
+		expected string
+	}{
+		{"8d6 +1d6/slot>3", 5, "10d6"},
+		{"1d10 +1d10@5,11,17", 11, "3d10"},
+		{"2d8 +1d8/level>1", 4, "5d8"},
+		{"3d4+3 +1d4+1/slot>1", 3, "5d4+5"},
+	}
+	for _, test := range tests {
+		s, err := ParseScaling(test.str)
+		if err != nil {
+			t.Errorf("Unexpected error parsing `%s`: %v", test.str, err)
+			continue
+		}
+		if s.String() != test.str {
+			t.Errorf("Expected `%s`, got `%s`", test.str, s)
+		}
+		if d := s.Dice(test.level); d.String() != test.expected {
+			t.Errorf("Expected `%s` for `%s` at level %d, got `%s`", test.expected, test.str, test.level, d)
+		}
+	}
+
+	s, err := ParseScaling("8d6 +1d6/slot>3", WithDamageType(Fire))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d := s.Dice(4); d.DamageType() != Fire || d.String() != "9d6 fire" {
+		t.Errorf("Expected `9d6 fire`, got `%s`", d)
+	}
+}
+
+// TestParseScalingInvalid tests parsing invalid scaling strings
+func TestParseScalingInvalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"8d6",
+		"8d6 1d6/slot>3",
+		"8d6 +1d6",
+		"8d6 +1d6/slot",
+		"8d6 +1d6/round>3",
+		"8d6 +1d6/slot>x",
+		"8d6 +1d6@5,x",
+		"fire +1d6/slot>3",
+	}
+	for _, str := range invalid {
+		if _, err := ParseScaling(str); !errors.Is(err, ErrInvalidScaling) {
+			t.Errorf("Expected ErrInvalidScaling parsing `%s`, got %v", str, err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package dice
+
+import "math/rand"
+
+// shuffleBag holds the faces of a dice in a shuffled order, so that every face is drawn once per
+// cycle through the bag. This reduces streaks of high or low rolls while keeping each face equally
+// likely overall.
+type shuffleBag struct {
+	copies int        // The number of copies of each face in the bag
+	faces  []int      // The faces left in the bag, with the next face drawn last
+	random *rand.Rand // The random number generator that shuffled the bag
+}
+
+// newShuffleBag returns an empty shuffle bag with the number of copies of each face. The bag is
+// filled the first time a face is drawn.
+func newShuffleBag(copies int) *shuffleBag {
+	return &shuffleBag{
+		copies: copies,
+	}
+}
+
+// WithShuffleBag draws the faces of the dice from a shuffled bag holding each face the number of
+// times given by copies, instead of rolling them. Every face comes up exactly that many times before
+// the bag is refilled and shuffled again; more copies allow more streaks. All dice rolled together
+// share the bag, so a 3d6 draws three faces from one bag of sixes. A copies value of 0 or less turns
+// the shuffle bag off.
+func WithShuffleBag(copies int) DiceOption {
+	return func(d *dice) {
+		if copies <= 0 {
+			d.shuffleBag = nil
+			return
+		}
+		d.shuffleBag = newShuffleBag(copies)
+	}
+}
+
+// rollFace rolls a single die using the random number generator, drawing the face from the shuffle bag
+// if the dice has one.
+func (d *dice) rollFace(random *rand.Rand) int {
+	if d.shuffleBag != nil {
+		return d.shuffleBag.draw(d.numSides, random)
+	}
+	return random.Intn(d.numSides) + 1 // Intn returns a value in the range [0, n), so we add 1 to get [1, n]
+}
+
+// draw draws the next face from the bag, refilling and shuffling the bag when it is empty or was
+// shuffled by a different random number generator, such as after Seed is called. This keeps the faces
+// drawn after seeding the same each time.
+func (sb *shuffleBag) draw(numSides int, random *rand.Rand) int {
+	if len(sb.faces) == 0 || sb.random != random {
+		sb.random = random
+		sb.faces = make([]int, 0, numSides*sb.copies)
+		for face := 1; face <= numSides; face++ {
+			for range sb.copies {
+				sb.faces = append(sb.faces, face)
+			}
+		}
+		random.Shuffle(len(sb.faces), func(i, j int) {
+			sb.faces[i], sb.faces[j] = sb.faces[j], sb.faces[i]
+		})
+	}
+
+	face := sb.faces[len(sb.faces)-1]
+	sb.faces = sb.faces[:len(sb.faces)-1]
+	return face
+}
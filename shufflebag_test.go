@@ -0,0 +1,71 @@
+package dice
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestShuffleBagCycle tests that every face is rolled once per cycle through the bag
+func TestShuffleBagCycle(t *testing.T) {
+	d := NewDice(1, 6, WithShuffleBag(1))
+	for cycle := 0; cycle < 100; cycle++ {
+		counts := make(map[int]int)
+		for range 6 {
+			counts[d.Roll().Value()]++
+		}
+		for face := 1; face <= 6; face++ {
+			if counts[face] != 1 {
+				t.Fatalf("Expected each face once per cycle, got %v", counts)
+			}
+		}
+	}
+
+	// With more copies, each face comes up that many times per cycle
+	d = NewDice(1, 4, WithShuffleBag(3))
+	counts := make(map[int]int)
+	for range 12 {
+		counts[d.Roll().Value()]++
+	}
+	for face := 1; face <= 4; face++ {
+		if counts[face] != 3 {
+			t.Errorf("Expected each face 3 times per cycle, got %v", counts)
+		}
+	}
+
+	// Dice rolled together share the bag
+	d = NewDice(2, 3, WithShuffleBag(1))
+	total := 0
+	for range 3 {
+		total += d.Roll().Value()
+	}
+	if total != 12 {
+		t.Errorf("Expected two cycles of 1+2+3, got %d", total)
+	}
+}
+
+// TestShuffleBagOption tests turning the shuffle bag on and off
+func TestShuffleBagOption(t *testing.T) {
+	d := ParseDice("1d20+2", WithShuffleBag(1)).(*dice)
+	if d.shuffleBag == nil {
+		t.Fatalf("Expected the dice to use a shuffle bag")
+	}
+	r := d.Roll()
+	if !strings.Contains(r.String(), ", Shuffle Bag)") {
+		t.Errorf("Expected the roll to note the shuffle bag, got %s", r)
+	}
+	if !strings.Contains(r.GetAllRolls()[0].String(), ", Shuffle Bag)") {
+		t.Errorf("Expected the single roll to note the shuffle bag, got %s", r.GetAllRolls()[0])
+	}
+
+	// Customized dice get their own bag
+	custom := d.Customize(WithSource("Fair")).(*dice)
+	if custom.shuffleBag == nil || custom.shuffleBag == d.shuffleBag {
+		t.Errorf("Expected the customized dice to have its own shuffle bag")
+	}
+	if plain := d.Customize(WithShuffleBag(0)).(*dice); plain.shuffleBag != nil {
+		t.Errorf("Expected the shuffle bag to be turned off")
+	}
+	if strings.Contains(D20.Roll().String(), "Shuffle Bag") {
+		t.Errorf("Expected a plain roll without a shuffle bag")
+	}
+}
@@ -0,0 +1,312 @@
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	MaxTableDepth   = 10  // The deepest sub-tables are rolled on, to prevent tables that reference each other from looping forever
+	MaxTableRerolls = 100 // The maximum number of times a "roll twice" entry is re-rolled when rolling twice
+)
+
+var (
+	ErrTableEntry   = errors.New("table entry has an invalid range")
+	ErrTableGap     = errors.New("table does not cover every result of the dice")
+	ErrTableOverlap = errors.New("table entries overlap")
+)
+
+// TableEntry is an entry in a random table, selected when the dice roll a value in its range.
+type TableEntry struct {
+	Min       int    // The lowest value that selects the entry
+	Max       int    // The highest value that selects the entry
+	Text      string // The text of the entry
+	Table     Table  // If set, the table that is rolled on when the entry is selected
+	RollTwice bool   // If true, the table is rolled on twice more when the entry is selected
+}
+
+// Table is a random table, such as an encounter or loot table, where each entry covers a range of the
+// values that can be rolled on the dice.
+type Table interface {
+	Name() string                        // The name of the table
+	Dice() Dice                          // The dice rolled on the table
+	Entries() []TableEntry               // The entries in the table
+	Entry(value int) (TableEntry, bool)  // The entry selected by the value, if there is one
+	Roll(opts ...RollOption) TableResult // Rolls on the table
+	fmt.Stringer                         // String representation of the table
+}
+
+// TableResult is the result of rolling on a random table.
+type TableResult interface {
+	Table() Table           // The table that was rolled on
+	Roll() Roll             // The roll of the table's dice
+	Entry() TableEntry      // The entry selected by the roll
+	Results() []TableResult // The results of rolling on a sub-table or rolling twice
+	Rerolls() []Roll        // Rolls that were ignored because they selected "roll twice" when already rolling twice
+	Text() []string         // The text of each entry that is not resolved by further rolls
+	fmt.Stringer            // String representation of the result, including any further rolls
+}
+
+// table is an implementation of the Table interface.
+type table struct {
+	name    string       // The name of the table
+	dice    Dice         // The dice rolled on the table
+	entries []TableEntry // The entries in the table
+}
+
+// tableResult is an implementation of the TableResult interface.
+type tableResult struct {
+	table   Table         // The table that was rolled on
+	roll    Roll          // The roll of the table's dice
+	entry   TableEntry    // The entry selected by the roll
+	results []TableResult // The results of further rolls
+	rerolls []Roll        // Rolls of "roll twice" that were ignored
+}
+
+// NewTable creates a random table that is rolled on using the dice. An error is returned if an entry's
+// range is invalid, if entries overlap, or if any value that can be rolled on the dice is not covered
+// by an entry.
+func NewTable(name string, d Dice, entries ...TableEntry) (Table, error) {
+	t := &table{
+		name:    name,
+		dice:    d,
+		entries: make([]TableEntry, 0, len(entries)),
+	}
+	t.entries = append(t.entries, entries...)
+
+	if _, err := validateTable(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// validateTable checks that the entries in the table cover every value of the dice exactly once. If
+// an entry is at fault, its index is returned along with the error; otherwise the index is -1.
+func validateTable(t *table) (int, error) {
+	for i, entry := range t.entries {
+		if entry.Min > entry.Max {
+			return i, fmt.Errorf("%w: %s", ErrTableEntry, formatRange(entry.Min, entry.Max))
+		}
+	}
+
+	order := make([]int, len(t.entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return t.entries[order[i]].Min < t.entries[order[j]].Min
+	})
+	for i := 1; i < len(order); i++ {
+		prev, entry := t.entries[order[i-1]], t.entries[order[i]]
+		if entry.Min <= prev.Max {
+			return order[i], fmt.Errorf("%w: %s and %s", ErrTableOverlap,
+				formatRange(prev.Min, prev.Max), formatRange(entry.Min, entry.Max))
+		}
+	}
+
+	values := make([]int, 0)
+	for value, p := range diceDistribution(t.dice) {
+		if p > 0 {
+			values = append(values, value)
+		}
+	}
+	sort.Ints(values)
+	for _, value := range values {
+		if _, ok := t.Entry(value); !ok {
+			return -1, fmt.Errorf("%w: %d (%s)", ErrTableGap, value, t.dice)
+		}
+	}
+
+	return -1, nil
+}
+
+// Name returns the name of the table.
+func (t *table) Name() string {
+	return t.name
+}
+
+// Dice returns the dice rolled on the table.
+func (t *table) Dice() Dice {
+	return t.dice
+}
+
+// Entries returns the entries in the table.
+func (t *table) Entries() []TableEntry {
+	return t.entries
+}
+
+// Entry returns the entry selected by the value, and `true` if there is one; `false` otherwise
+func (t *table) Entry(value int) (TableEntry, bool) {
+	for _, entry := range t.entries {
+		if value >= entry.Min && value <= entry.Max {
+			return entry, true
+		}
+	}
+	return TableEntry{}, false
+}
+
+// Roll rolls on the table using the options, returning the roll and the selected entry. If the entry
+// references a sub-table, the sub-table is rolled on as well. If the entry says to roll twice, the table
+// is rolled on twice more, re-rolling any further "roll twice" results.
+func (t *table) Roll(opts ...RollOption) TableResult {
+	return t.roll(0, opts...)
+}
+
+// roll rolls on the table, tracking how deeply sub-tables have been rolled on.
+func (t *table) roll(depth int, opts ...RollOption) *tableResult {
+	r := t.dice.Roll(opts...)
+	entry, _ := t.Entry(r.Value())
+	return t.resolve(r, entry, depth, opts)
+}
+
+// resolve returns the result for the roll and the entry it selected, making any further rolls on
+// sub-tables or this table that the entry requires.
+func (t *table) resolve(r Roll, entry TableEntry, depth int, opts []RollOption) *tableResult {
+	tr := &tableResult{
+		table: t,
+		roll:  r,
+		entry: entry,
+	}
+	if depth >= MaxTableDepth {
+		return tr
+	}
+
+	if entry.RollTwice {
+		for range 2 {
+			twice := t.dice.Roll(opts...)
+			twiceEntry, _ := t.Entry(twice.Value())
+			for attempt := 0; twiceEntry.RollTwice && attempt < MaxTableRerolls; attempt++ {
+				tr.rerolls = append(tr.rerolls, twice)
+				twice = t.dice.Roll(opts...)
+				twiceEntry, _ = t.Entry(twice.Value())
+			}
+			tr.results = append(tr.results, t.resolve(twice, twiceEntry, depth+1, opts))
+		}
+	}
+	if entry.Table != nil {
+		// Sub-tables are rolled with the same random number generator, but none of the other options
+		subOpts := randomOptions(opts)
+		if sub, ok := entry.Table.(*table); ok {
+			tr.results = append(tr.results, sub.roll(depth+1, subOpts...))
+		} else {
+			tr.results = append(tr.results, entry.Table.Roll(subOpts...))
+		}
+	}
+
+	return tr
+}
+
+// Table returns the table that was rolled on.
+func (tr *tableResult) Table() Table {
+	return tr.table
+}
+
+// Roll returns the roll of the table's dice.
+func (tr *tableResult) Roll() Roll {
+	return tr.roll
+}
+
+// Entry returns the entry selected by the roll.
+func (tr *tableResult) Entry() TableEntry {
+	return tr.entry
+}
+
+// Results returns the results of rolling on a sub-table or rolling twice.
+func (tr *tableResult) Results() []TableResult {
+	return tr.results
+}
+
+// Rerolls returns the rolls that were ignored because they selected "roll twice" when already rolling twice.
+func (tr *tableResult) Rerolls() []Roll {
+	return tr.rerolls
+}
+
+// Text returns the text of each entry that is not resolved by further rolls, in the order they were rolled.
+// An entry that references a sub-table includes its own text, if any, before the text from the sub-table.
+func (tr *tableResult) Text() []string {
+	var text []string
+	if tr.entry.Text != "" && (len(tr.results) == 0 || !tr.entry.RollTwice) {
+		text = append(text, tr.entry.Text)
+	}
+	for _, result := range tr.results {
+		text = append(text, result.Text()...)
+	}
+	return text
+}
+
+// formatRange returns a string representation of a range of values, such as `16-40` or `7`.
+func formatRange(minValue, maxValue int) string {
+	if minValue == maxValue {
+		return strconv.Itoa(minValue)
+	}
+	return strconv.Itoa(minValue) + "-" + strconv.Itoa(maxValue)
+}
+
+// String returns a string representation of the table, with the name and dice followed by each entry
+// on a separate line.
+func (t *table) String() string {
+	var sb strings.Builder
+
+	if t.name != "" {
+		sb.WriteString(t.name)
+		sb.WriteString(" ")
+	}
+	sb.WriteString("(")
+	sb.WriteString(t.dice.String())
+	sb.WriteString(")")
+	for _, entry := range t.entries {
+		sb.WriteString("\n")
+		sb.WriteString(formatRange(entry.Min, entry.Max))
+		sb.WriteString(": ")
+		sb.WriteString(entry.String())
+	}
+
+	return sb.String()
+}
+
+// String returns a string representation of the entry, including any sub-table or "roll twice" instruction.
+func (e TableEntry) String() string {
+	parts := make([]string, 0, 3)
+	if e.Text != "" {
+		parts = append(parts, e.Text)
+	}
+	if e.RollTwice {
+		parts = append(parts, "[roll twice]")
+	}
+	if e.Table != nil {
+		parts = append(parts, "[roll on "+e.Table.Name()+"]")
+	}
+	return strings.Join(parts, " ")
+}
+
+// String returns a string representation of the result, such as `Encounters: 37 (1d100) = 37: Goblins`,
+// with the result of each further roll indented on a separate line.
+func (tr *tableResult) String() string {
+	var sb strings.Builder
+	tr.writeString(&sb, 0)
+	return sb.String()
+}
+
+// writeString writes the result, and the results of any further rolls, indented to the depth.
+func (tr *tableResult) writeString(sb *strings.Builder, depth int) {
+	sb.WriteString(strings.Repeat("  ", depth))
+	if tr.table.Name() != "" {
+		sb.WriteString(tr.table.Name())
+		sb.WriteString(": ")
+	}
+	sb.WriteString(tr.roll.String())
+	sb.WriteString(": ")
+	sb.WriteString(tr.entry.String())
+	for _, result := range tr.results {
+		sb.WriteString("\n")
+		if r, ok := result.(*tableResult); ok {
+			r.writeString(sb, depth+1)
+		} else {
+			sb.WriteString(strings.Repeat("  ", depth+1))
+			sb.WriteString(result.String())
+		}
+	}
+}
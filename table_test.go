@@ -0,0 +1,155 @@
+package dice
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestNewTableValidation tests validating the ranges of a table's entries
+func TestNewTableValidation(t *testing.T) {
+	tests := []struct {
+		dice     Dice
+		entries  []TableEntry
+		expected error
+	}{
+		{D6, []TableEntry{{Min: 1, Max: 2}, {Min: 3, Max: 5}, {Min: 6, Max: 6}}, nil},
+		{D6, []TableEntry{{Min: 6, Max: 6}, {Min: 1, Max: 5}}, nil},
+		{ParseDice("2d6"), []TableEntry{{Min: 2, Max: 6}, {Min: 7, Max: 7}, {Min: 8, Max: 12}}, nil},
+		{D6, []TableEntry{{Min: 1, Max: 2}, {Min: 4, Max: 6}}, ErrTableGap},
+		{D6, []TableEntry{{Min: 1, Max: 3}, {Min: 3, Max: 6}}, ErrTableOverlap},
+		{D6, []TableEntry{{Min: 1, Max: 6}, {Min: 10, Max: 12}, {Min: 11, Max: 11}}, ErrTableOverlap},
+		{D6, []TableEntry{{Min: 4, Max: 1}, {Min: 1, Max: 6}}, ErrTableEntry},
+		{ParseDice("2d6"), []TableEntry{{Min: 1, Max: 11}}, ErrTableGap},
+	}
+
+	for i, test := range tests {
+		_, err := NewTable("Test", test.dice, test.entries...)
+		if test.expected == nil && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i, err)
+		} else if !errors.Is(err, test.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i, test.expected, err)
+		}
+	}
+}
+
+// TestTableRoll tests rolling on a table
+func TestTableRoll(t *testing.T) {
+	table, err := NewTable("Encounters", D100,
+		TableEntry{Min: 1, Max: 15, Text: "Goblins"},
+		TableEntry{Min: 16, Max: 40, Text: "Wolves"},
+		TableEntry{Min: 41, Max: 100, Text: "Nothing"},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		result := table.Roll()
+		value := result.Roll().Value()
+		entry, _ := table.Entry(value)
+		if result.Entry().Text != entry.Text {
+			t.Errorf("Expected %d to select `%s`, got `%s`", value, entry.Text, result.Entry().Text)
+		}
+		if len(result.Text()) != 1 || result.Text()[0] != entry.Text {
+			t.Errorf("Expected text `%s`, got %v", entry.Text, result.Text())
+		}
+		if result.Table() != table || len(result.Results()) != 0 {
+			t.Errorf("Unexpected result %s", result)
+		}
+	}
+
+	result := table.Roll(WithAdvantage())
+	if !result.Roll().RolledWithAdvantage() {
+		t.Errorf("Expected the table to be rolled with advantage, got %s", result)
+	}
+}
+
+// TestTableSubTable tests entries that reference a sub-table
+func TestTableSubTable(t *testing.T) {
+	gems, err := NewTable("Gems", D4, TableEntry{Min: 1, Max: 4, Text: "Ruby"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	loot, err := NewTable("Loot", NewConstant(1), TableEntry{Min: 1, Max: 1, Text: "Treasure", Table: gems})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result := loot.Roll()
+	text := result.Text()
+	if len(text) != 2 || text[0] != "Treasure" || text[1] != "Ruby" {
+		t.Errorf("Expected text [Treasure Ruby], got %v", text)
+	}
+	if len(result.Results()) != 1 || result.Results()[0].Table() != gems {
+		t.Errorf("Expected a result from the sub-table, got %s", result)
+	}
+
+	lines := strings.Split(result.String(), "\n")
+	if len(lines) != 2 || lines[0] != "Loot: 1 = 1: Treasure [roll on Gems]" || !strings.HasPrefix(lines[1], "  Gems: ") {
+		t.Errorf("Unexpected result string `%s`", result)
+	}
+
+	// A table that references itself stops after the maximum depth
+	cycle, err := NewTable("Cycle", NewConstant(1), TableEntry{Min: 1, Max: 1, Text: "Again"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	cycle.(*table).entries[0].Table = cycle
+	if text := cycle.Roll().Text(); len(text) != MaxTableDepth+1 {
+		t.Errorf("Expected %d results, got %d", MaxTableDepth+1, len(text))
+	}
+}
+
+// TestTableRollTwice tests entries that roll on the table twice
+func TestTableRollTwice(t *testing.T) {
+	table, err := NewTable("Treasure", ParseDice("1d2"),
+		TableEntry{Min: 1, Max: 1, Text: "Roll twice", RollTwice: true},
+		TableEntry{Min: 2, Max: 2, Text: "Gold"},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	found := false
+	for i := 0; i < 100; i++ {
+		result := table.Roll()
+		if !result.Entry().RollTwice {
+			continue
+		}
+		found = true
+
+		if len(result.Results()) != 2 {
+			t.Fatalf("Expected 2 results, got %d", len(result.Results()))
+		}
+		text := result.Text()
+		if len(text) != 2 || text[0] != "Gold" || text[1] != "Gold" {
+			t.Errorf("Expected text [Gold Gold], got %v", text)
+		}
+		for _, reroll := range result.Rerolls() {
+			if reroll.Value() != 1 {
+				t.Errorf("Expected only `roll twice` to be re-rolled, got %s", reroll)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected to roll `roll twice` at least once")
+	}
+}
+
+// TestTableString tests the string representation of a table
+func TestTableString(t *testing.T) {
+	table, err := NewTable("Weather", D6,
+		TableEntry{Min: 1, Max: 3, Text: "Clear"},
+		TableEntry{Min: 4, Max: 5, Text: "Rain"},
+		TableEntry{Min: 6, Max: 6, RollTwice: true},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "Weather (1d6)\n1-3: Clear\n4-5: Rain\n6: [roll twice]"
+	if table.String() != expected {
+		t.Errorf("Expected `%s`, got `%s`", expected, table)
+	}
+}
@@ -0,0 +1,620 @@
+package dice
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TableFormat is the format of a file containing the definition of a random table.
+type TableFormat string
+
+const (
+	TableJSON TableFormat = "json" // A JSON object with the name, dice and entries of the table
+	TableYAML TableFormat = "yaml" // A YAML document with the name, dice and entries of the table
+	TableCSV  TableFormat = "csv"  // A header row followed by a row for each entry of the table
+)
+
+var (
+	ErrTableFormat    = errors.New("invalid table definition")
+	ErrTableNotFound  = errors.New("table not found")
+	ErrTableDuplicate = errors.New("table already exists")
+)
+
+// yamlLinePattern matches the line number included in errors returned when parsing YAML.
+var yamlLinePattern = regexp.MustCompile(`line (\d+): `)
+
+// tableRangePattern matches a range of values in a table, such as `01-15`, `16` or `-2--1`.
+var tableRangePattern = regexp.MustCompile(`^\s*(-?\d+)\s*(?:-\s*(-?\d+)\s*)?$`)
+
+// TableRegistry is a collection of random tables that can be looked up by name.
+type TableRegistry interface {
+	Table(name string) (Table, error)                          // The table with the name
+	Names() []string                                           // The names of the tables, in sorted order
+	Roll(name string, opts ...RollOption) (TableResult, error) // Rolls on the table with the name
+}
+
+// tableRegistry is an implementation of the TableRegistry interface.
+type tableRegistry map[string]Table
+
+// tableDefinition is the definition of a table read from a file, before any references to other
+// tables are resolved.
+type tableDefinition struct {
+	Name    string            `json:"name" yaml:"name"`       // The name of the table
+	Dice    string            `json:"dice" yaml:"dice"`       // The dice rolled on the table
+	Entries []entryDefinition `json:"entries" yaml:"entries"` // The entries in the table
+	file    string            // The file the table was read from
+	line    int               // The line the table starts on
+}
+
+// entryDefinition is the definition of an entry in a table read from a file.
+type entryDefinition struct {
+	Range     tableRange `json:"range" yaml:"range"`         // The range of values, such as `01-15`
+	Weight    *int       `json:"weight" yaml:"weight"`       // The number of values covered after the previous entry, if there is no range
+	Text      string     `json:"text" yaml:"text"`           // The text of the entry
+	Table     string     `json:"table" yaml:"table"`         // The name of the table rolled on when the entry is selected
+	RollTwice bool       `json:"rollTwice" yaml:"rollTwice"` // If true, the table is rolled on twice more
+	line      int        // The line the entry starts on
+}
+
+// tableRange is the range of values covered by an entry, which may be written as a string or a number.
+type tableRange string
+
+// ParseTable parses the definition of a single table in the format. If the definition does not include
+// a name, the provided name is used. Entries may refer to the table itself or to any of the provided
+// tables by name. Errors include the line in the definition that caused them.
+func ParseTable(format TableFormat, name string, data []byte, tables ...Table) (Table, error) {
+	def, err := parseTableDefinition(format, name, name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := make(tableRegistry, len(tables)+1)
+	for _, t := range tables {
+		registry[t.Name()] = t
+	}
+	if err := registry.build([]*tableDefinition{def}); err != nil {
+		return nil, err
+	}
+	return registry[def.Name], nil
+}
+
+// LoadTableRegistry loads the tables defined in the files of the file system, such as one created
+// with `embed`, that match any of the patterns. If no patterns are provided, every file is loaded.
+// The format of each file is determined by its extension, which must be `.json`, `.yaml`, `.yml` or
+// `.csv`, and tables without a name are named after their file. Entries may refer to any table in the
+// registry by name. Errors include the file and line that caused them.
+func LoadTableRegistry(fsys fs.FS, patterns ...string) (TableRegistry, error) {
+	var files []string
+	if len(patterns) == 0 {
+		err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && tableFormatOf(p) != "" {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			if tableFormatOf(match) != "" && !containsString(files, match) {
+				files = append(files, match)
+			}
+		}
+	}
+	sort.Strings(files)
+
+	defs := make([]*tableDefinition, 0, len(files))
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(path.Base(file), path.Ext(file))
+		def, err := parseTableDefinition(tableFormatOf(file), file, name, data)
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, def)
+	}
+
+	registry := make(tableRegistry, len(defs))
+	if err := registry.build(defs); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// tableFormatOf returns the format of the file based on its extension, or an empty format if the
+// extension is not supported.
+func tableFormatOf(file string) TableFormat {
+	switch strings.ToLower(path.Ext(file)) {
+	case ".json":
+		return TableJSON
+	case ".yaml", ".yml":
+		return TableYAML
+	case ".csv":
+		return TableCSV
+	default:
+		return ""
+	}
+}
+
+// parseTableDefinition parses the definition of a table in the format.
+func parseTableDefinition(format TableFormat, file string, name string, data []byte) (*tableDefinition, error) {
+	var def *tableDefinition
+	var err error
+	switch format {
+	case TableJSON:
+		def, err = parseTableJSON(file, data)
+	case TableYAML:
+		def, err = parseTableYAML(file, data)
+	case TableCSV:
+		def, err = parseTableCSV(file, data)
+	default:
+		return nil, fmt.Errorf("%s: %w: unknown format %q", file, ErrTableFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if def.Name == "" {
+		def.Name = name
+	}
+	return def, nil
+}
+
+// parseTableJSON parses the definition of a table from a JSON object. The object is read a token at
+// a time so that the line each entry starts on is known.
+func parseTableJSON(file string, data []byte) (*tableDefinition, error) {
+	def := &tableDefinition{
+		file: file,
+		line: 1,
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		return nil, jsonError(file, data, dec, err)
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, jsonError(file, data, dec, err)
+		}
+		switch token {
+		case "name":
+			err = dec.Decode(&def.Name)
+		case "dice":
+			err = dec.Decode(&def.Dice)
+		case "entries":
+			err = parseJSONEntries(dec, data, def)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return nil, jsonError(file, data, dec, err)
+		}
+	}
+	if err := expectJSONDelim(dec, '}'); err != nil {
+		return nil, jsonError(file, data, dec, err)
+	}
+
+	return def, nil
+}
+
+// parseJSONEntries parses the array of entries in a JSON table definition.
+func parseJSONEntries(dec *json.Decoder, data []byte, def *tableDefinition) error {
+	if err := expectJSONDelim(dec, '['); err != nil {
+		return err
+	}
+	for dec.More() {
+		offset := dec.InputOffset()
+		var entry entryDefinition
+		if err := dec.Decode(&entry); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				typeErr.Offset += offset
+			}
+			return err
+		}
+		entry.line = lineAt(data, offset)
+		def.Entries = append(def.Entries, entry)
+	}
+	return expectJSONDelim(dec, ']')
+}
+
+// expectJSONDelim reads the next token, returning an error if it is not the delimiter.
+func expectJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %q, found %v", delim, token)
+	}
+	return nil
+}
+
+// jsonError returns the error with the file and the line it occurred on.
+func jsonError(file string, data []byte, dec *json.Decoder, err error) error {
+	offset := dec.InputOffset()
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset - 1
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	case errors.Is(err, io.EOF):
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("%s:%d: %w: %v", file, lineAt(data, offset), ErrTableFormat, err)
+}
+
+// lineAt returns the line of the first character at or after the offset that is not whitespace or a
+// comma, which is where the next value in a JSON array or object starts.
+func lineAt(data []byte, offset int64) int {
+	pos := min(int(offset), len(data))
+	for pos < len(data) && strings.ContainsRune(" \t\r\n,", rune(data[pos])) {
+		pos++
+	}
+	return bytes.Count(data[:min(pos, len(data))], []byte("\n")) + 1
+}
+
+// parseTableYAML parses the definition of a table from a YAML document.
+func parseTableYAML(file string, data []byte) (*tableDefinition, error) {
+	def := &tableDefinition{
+		file: file,
+		line: 1,
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, yamlError(file, err)
+	}
+	if err := node.Decode(def); err != nil {
+		return nil, yamlError(file, err)
+	}
+	return def, nil
+}
+
+// UnmarshalYAML decodes the entry, recording the line it starts on.
+func (e *entryDefinition) UnmarshalYAML(node *yaml.Node) error {
+	type plain entryDefinition
+	var entry plain
+	if err := node.Decode(&entry); err != nil {
+		return err
+	}
+	*e = entryDefinition(entry)
+	e.line = node.Line
+	return nil
+}
+
+// UnmarshalYAML decodes the range from the text of a scalar, so that a range such as `00` or `7` is
+// not read as a number.
+func (r *tableRange) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: range must be a value such as 01-15", node.Line)
+	}
+	*r = tableRange(node.Value)
+	return nil
+}
+
+// UnmarshalJSON decodes the range from either a string or a number.
+func (r *tableRange) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case string:
+		*r = tableRange(v)
+	case float64:
+		*r = tableRange(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return fmt.Errorf("range must be a value such as \"01-15\", found %s", data)
+	}
+	return nil
+}
+
+// yamlError returns the error with the file and the line it occurred on.
+func yamlError(file string, err error) error {
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	msg = strings.TrimPrefix(msg, "unmarshal errors:\n  ")
+	if match := yamlLinePattern.FindStringSubmatchIndex(msg); match != nil {
+		line := msg[match[2]:match[3]]
+		msg = msg[:match[0]] + msg[match[1]:]
+		return fmt.Errorf("%s:%s: %w: %s", file, line, ErrTableFormat, msg)
+	}
+	return fmt.Errorf("%s: %w: %s", file, ErrTableFormat, msg)
+}
+
+// parseTableCSV parses the definition of a table from CSV. The first row is a header naming the
+// columns, which may include `range`, `weight`, `text`, `table`, `rollTwice` and `dice`. The dice
+// only needs to be included in one row.
+func parseTableCSV(file string, data []byte) (*tableDefinition, error) {
+	def := &tableDefinition{
+		file: file,
+		line: 1,
+	}
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, csvError(file, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	_, hasRange := columns["range"]
+	_, hasWeight := columns["weight"]
+	if !hasRange && !hasWeight {
+		return nil, fmt.Errorf("%s:1: %w: missing a range or weight column", file, ErrTableFormat)
+	}
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, csvError(file, err)
+		}
+		line, _ := r.FieldPos(0)
+		field := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		entry := entryDefinition{
+			Range: tableRange(field("range")),
+			Text:  field("text"),
+			Table: field("table"),
+			line:  line,
+		}
+		if weight := field("weight"); weight != "" {
+			w, err := strconv.Atoi(weight)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w: invalid weight %q", file, line, ErrTableFormat, weight)
+			}
+			entry.Weight = &w
+		}
+		if rollTwice := field("rolltwice"); rollTwice != "" {
+			if entry.RollTwice, err = parseCSVBool(rollTwice); err != nil {
+				return nil, fmt.Errorf("%s:%d: %w: invalid rollTwice %q", file, line, ErrTableFormat, rollTwice)
+			}
+		}
+		if dice := field("dice"); dice != "" && def.Dice == "" {
+			def.Dice = dice
+		}
+		def.Entries = append(def.Entries, entry)
+	}
+
+	return def, nil
+}
+
+// parseCSVBool parses a boolean value in a spreadsheet, which may be written as `yes`, `no` or `x`.
+func parseCSVBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "y", "x":
+		return true, nil
+	case "no", "n":
+		return false, nil
+	default:
+		return strconv.ParseBool(value)
+	}
+}
+
+// csvError returns the error with the file and the line it occurred on.
+func csvError(file string, err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return fmt.Errorf("%s:%d: %w: %v", file, parseErr.Line, ErrTableFormat, parseErr.Err)
+	}
+	return fmt.Errorf("%s: %w: %v", file, ErrTableFormat, err)
+}
+
+// build creates a table for each definition and adds it to the registry. The tables are created before
+// any entries are resolved, so that tables may refer to each other.
+func (tr tableRegistry) build(defs []*tableDefinition) error {
+	tables := make([]*table, 0, len(defs))
+	for _, def := range defs {
+		if _, ok := tr[def.Name]; ok {
+			return fmt.Errorf("%s:%d: %w: %s", def.file, def.line, ErrTableDuplicate, def.Name)
+		}
+		t := &table{
+			name: def.Name,
+		}
+		tr[def.Name] = t
+		tables = append(tables, t)
+	}
+
+	for i, def := range defs {
+		t := tables[i]
+		if err := def.resolve(t, tr); err != nil {
+			return err
+		}
+		if index, err := validateTable(t); err != nil {
+			line := def.line
+			if index >= 0 {
+				line = def.Entries[index].line
+			}
+			return fmt.Errorf("%s:%d: %w", def.file, line, err)
+		}
+	}
+
+	return nil
+}
+
+// resolve sets the dice and entries of the table from the definition, looking up any tables the
+// entries refer to in the registry. Entries with a weight instead of a range cover as many values as
+// the weight, starting after the previous entry, so reordering the entries changes the values that
+// select them. An error is returned if a weight is not positive or an entry has both a range and a
+// weight, or if the dice has no sides, such as `1d0`. The dice `d66` and `d666` are read as digit dice,
+// as is usual for tables. If the definition has no dice, a single dice with as many sides as the highest
+// value covered by the entries is used.
+func (def *tableDefinition) resolve(t *table, tables tableRegistry) error {
+	start := 1
+	if def.Dice != "" {
+		diceStr := strings.ToLower(strings.TrimSpace(def.Dice))
+		if !diceExpressionPattern.MatchString(diceStr) {
+			return fmt.Errorf("%s:%d: %w: invalid dice %q", def.file, def.line, ErrTableFormat, def.Dice)
+		}
+		switch diceStr {
+		case "d66", "d666":
+			t.dice, _ = ParseDigitDice(diceStr)
+		default:
+			t.dice = ParseDice(diceStr)
+			if d, ok := t.dice.(*dice); ok && d.numDice > 0 && d.numSides <= 0 {
+				return fmt.Errorf("%s:%d: %w: dice %q has no sides", def.file, def.line, ErrTableFormat, def.Dice)
+			}
+		}
+		first := true
+		for value := range diceDistribution(t.dice) {
+			if first || value < start {
+				start, first = value, false
+			}
+		}
+	}
+
+	t.entries = make([]TableEntry, 0, len(def.Entries))
+	next, highest := start, start
+	for _, e := range def.Entries {
+		entry := TableEntry{
+			Text:      e.Text,
+			RollTwice: e.RollTwice,
+		}
+		switch {
+		case e.Range != "" && e.Weight != nil:
+			return fmt.Errorf("%s:%d: %w: entry has both a range and a weight", def.file, e.line, ErrTableFormat)
+		case e.Range != "":
+			minValue, maxValue, err := parseTableRange(string(e.Range))
+			if err != nil {
+				return fmt.Errorf("%s:%d: %w", def.file, e.line, err)
+			}
+			entry.Min, entry.Max = minValue, maxValue
+		case e.Weight != nil && *e.Weight <= 0:
+			return fmt.Errorf("%s:%d: %w: weight must be positive, got %d", def.file, e.line, ErrTableFormat, *e.Weight)
+		case e.Weight != nil:
+			entry.Min, entry.Max = next, next+*e.Weight-1
+		default:
+			return fmt.Errorf("%s:%d: %w: entry needs a range or a positive weight", def.file, e.line, ErrTableFormat)
+		}
+		if e.Table != "" {
+			sub, ok := tables[e.Table]
+			if !ok {
+				return fmt.Errorf("%s:%d: %w: %s", def.file, e.line, ErrTableNotFound, e.Table)
+			}
+			entry.Table = sub
+		}
+		next = entry.Max + 1
+		highest = max(highest, entry.Max)
+		t.entries = append(t.entries, entry)
+	}
+
+	if t.dice == nil {
+		t.dice = NewDice(1, highest)
+	}
+	return nil
+}
+
+// parseTableRange parses a range of values, such as `01-15`, `16` or `96-00`, where `00` is 100. Values
+// can be negative, such as `-2--1`, for dice with a negative modifier.
+func parseTableRange(str string) (int, int, error) {
+	str = strings.ReplaceAll(str, "–", "-")
+	match := tableRangePattern.FindStringSubmatch(str)
+	if match == nil {
+		return 0, 0, fmt.Errorf("%w: invalid range %q", ErrTableEntry, str)
+	}
+	maxStr := match[2]
+	if maxStr == "" {
+		maxStr = match[1]
+	}
+
+	minValue, err := parseTableValue(match[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: invalid range %q", ErrTableEntry, str)
+	}
+	maxValue, err := parseTableValue(maxStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: invalid range %q", ErrTableEntry, str)
+	}
+	return minValue, maxValue, nil
+}
+
+// parseTableValue parses a single value in a range, where `00` is 100.
+func parseTableValue(str string) (int, error) {
+	str = strings.TrimSpace(str)
+	if str == "00" {
+		return 100, nil
+	}
+	return strconv.Atoi(str)
+}
+
+// containsString returns true if the value is included in the values.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Table returns the table with the name.
+func (tr tableRegistry) Table(name string) (Table, error) {
+	t, ok := tr[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTableNotFound, name)
+	}
+	return t, nil
+}
+
+// Names returns the names of the tables, in sorted order.
+func (tr tableRegistry) Names() []string {
+	names := make([]string, 0, len(tr))
+	for name := range tr {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Roll rolls on the table with the name using the options.
+func (tr tableRegistry) Roll(name string, opts ...RollOption) (TableResult, error) {
+	t, err := tr.Table(name)
+	if err != nil {
+		return nil, err
+	}
+	return t.Roll(opts...), nil
+}
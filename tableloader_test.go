@@ -0,0 +1,221 @@
+package dice
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// TestParseTableFormats tests parsing the same table from each format
+func TestParseTableFormats(t *testing.T) {
+	tests := []struct {
+		format TableFormat
+		data   string
+	}{
+		{TableJSON, `{
+  "name": "Encounters",
+  "dice": "1d100",
+  "entries": [
+    {"range": "01-15", "text": "Goblins"},
+    {"range": "16-40", "text": "Wolves"},
+    {"range": "41-00", "text": "Nothing"}
+  ]
+}`},
+		{TableYAML, `name: Encounters
+dice: 1d100
+entries:
+  - range: 01-15
+    text: Goblins
+  - range: 16-40
+    text: Wolves
+  - range: 41-00
+    text: Nothing
+`},
+		{TableCSV, `dice,range,text
+1d100,01-15,Goblins
+,16-40,Wolves
+,41-00,Nothing
+`},
+	}
+
+	for _, test := range tests {
+		table, err := ParseTable(test.format, "Encounters", []byte(test.data))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.format, err)
+		}
+		expected := "Encounters (1d100)\n1-15: Goblins\n16-40: Wolves\n41-100: Nothing"
+		if table.String() != expected {
+			t.Errorf("%s: expected `%s`, got `%s`", test.format, expected, table)
+		}
+	}
+}
+
+// TestParseTableWeights tests entries with weights instead of ranges
+func TestParseTableWeights(t *testing.T) {
+	// The dice is inferred from the total weight
+	table, err := ParseTable(TableYAML, "Weather", []byte(`entries:
+  - weight: 3
+    text: Clear
+  - weight: 2
+    text: Rain
+  - weight: 1
+    text: Storm
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "Weather (1d6)\n1-3: Clear\n4-5: Rain\n6: Storm"
+	if table.String() != expected {
+		t.Errorf("Expected `%s`, got `%s`", expected, table)
+	}
+
+	// Weights start at the lowest value of the dice
+	table, err = ParseTable(TableCSV, "Reaction", []byte("Dice,Weight,Text\n2d6,4,Hostile\n,5,Wary\n,2,Neutral\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected = "Reaction (2d6)\n2-5: Hostile\n6-10: Wary\n11-12: Neutral"
+	if table.String() != expected {
+		t.Errorf("Expected `%s`, got `%s`", expected, table)
+	}
+}
+
+// TestParseTableDice tests dice written in upper case and dice that can roll negative values
+func TestParseTableDice(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected string
+	}{
+		{"dice,range,text\n1D6,1-3,Low\n,4-6,High\n", "Test (1d6)\n1-3: Low\n4-6: High"},
+		{"dice,range,text\nD66,11-36,Low\n,41-66,High\n", "Test (d66)\n11-36: Low\n41-66: High"},
+		{"dice,range,text\n1d6-3,-2--1,Low\n,0,Even\n,1-3,High\n", "Test (1d6-3)\n-2--1: Low\n0: Even\n1-3: High"},
+		{"dice,weight,text\n1d6-3,2,Low\n,4,High\n", "Test (1d6-3)\n-2--1: Low\n0-3: High"},
+	}
+
+	for _, test := range tests {
+		table, err := ParseTable(TableCSV, "Test", []byte(test.data))
+		if err != nil {
+			t.Errorf("Unexpected error for `%s`: %v", test.data, err)
+		} else if table.String() != test.expected {
+			t.Errorf("Expected `%s`, got `%s`", test.expected, table)
+		}
+	}
+}
+
+// TestParseTableErrors tests that validation errors include the line that caused them
+func TestParseTableErrors(t *testing.T) {
+	tests := []struct {
+		format   TableFormat
+		data     string
+		expected error
+		prefix   string
+	}{
+		{TableJSON, "{\n  \"dice\": \"1d6\",\n  \"entries\": [\n    {\"range\": \"1-3\"},\n    {\"range\": \"3-6\"}\n  ]\n}", ErrTableOverlap, "test:5:"},
+		{TableJSON, "{\n  \"dice\": \"1d6\",\n  \"entries\": [\n    {\"range\": \"1-3\"},\n    {\"range\": 4, \"weight\": \"x\"}\n  ]\n}", ErrTableFormat, "test:5:"},
+		{TableJSON, "{\n  \"dice\": \"1d6\",\n  \"entries\": [\n    {\"range\": \"1-3\",}\n  ]\n}", ErrTableFormat, "test:4:"},
+		{TableYAML, "dice: 1d6\nentries:\n  - range: 1-3\n  - range: 6-4\n", ErrTableEntry, "test:4:"},
+		{TableYAML, "dice: 1d6\nentries:\n  - range: 1-3\n  - range: 5-6\n", ErrTableGap, "test:1:"},
+		{TableYAML, "dice: 1d6\nentries:\n  - range: 1-6\n    weight: many\n", ErrTableFormat, "test:4:"},
+		{TableYAML, "dice: 1d6\nentries:\n  - range: 1-6\n    table: Missing\n", ErrTableNotFound, "test:3:"},
+		{TableYAML, "dice: lots\nentries:\n  - range: 1-6\n", ErrTableFormat, "test:1:"},
+		{TableYAML, "dice: 1d6\nentries:\n  - weight: 6\n  - weight: 0\n", ErrTableFormat, "test:4:"},
+		{TableYAML, "dice: 1d6\nentries:\n  - range: 1-6\n    weight: 6\n", ErrTableFormat, "test:3:"},
+		{TableJSON, "{\n  \"entries\": [\n    {\"weight\": 6},\n    {\"weight\": -1}\n  ]\n}", ErrTableFormat, "test:4:"},
+		{TableCSV, "weight,text\n6,Clear\n0,Rain\n", ErrTableFormat, "test:3:"},
+		{TableCSV, "range,text\n1-3,Clear\n\n4-x,Rain\n", ErrTableEntry, "test:4:"},
+		{TableCSV, "range,text,rollTwice\n1-3,Clear,maybe\n", ErrTableFormat, "test:2:"},
+		{TableCSV, "text\nClear\n", ErrTableFormat, "test:1:"},
+		{TableCSV, "range,text\n1-6,\"Clear\n", ErrTableFormat, "test:2:"},
+		{TableJSON, "{\n  \"dice\": \"1d0\",\n  \"entries\": [\n    {\"range\": \"1\"}\n  ]\n}", ErrTableFormat, "test:1:"},
+		{TableYAML, "dice: 1d6\nentries:\n  - range: 1--2\n", ErrTableEntry, "test:3:"},
+		{TableYAML, "dice: 1d6\nentries:\n  - range: 1-2-3\n", ErrTableEntry, "test:3:"},
+	}
+
+	for i, test := range tests {
+		_, err := ParseTable(test.format, "test", []byte(test.data))
+		if !errors.Is(err, test.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i, test.expected, err)
+		} else if !strings.HasPrefix(err.Error(), test.prefix) {
+			t.Errorf("Test %d: expected the error to start with `%s`, got `%v`", i, test.prefix, err)
+		}
+	}
+}
+
+// TestParseTableReferences tests entries that reference other tables
+func TestParseTableReferences(t *testing.T) {
+	gems, err := NewTable("Gems", D4, TableEntry{Min: 1, Max: 4, Text: "Ruby"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	loot, err := ParseTable(TableJSON, "Loot", []byte(`{"dice": "1d2", "entries": [
+  {"range": 1, "text": "Treasure", "table": "Gems"},
+  {"range": 2, "rollTwice": true}
+]}`), gems)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	entries := loot.Entries()
+	if entries[0].Table != gems || !entries[1].RollTwice {
+		t.Errorf("Unexpected entries %s", loot)
+	}
+}
+
+// TestLoadTableRegistry tests loading tables from a file system
+func TestLoadTableRegistry(t *testing.T) {
+	fsys := fstest.MapFS{
+		"tables/loot.yaml": {Data: []byte("name: Loot\ndice: 1d2\nentries:\n  - range: 1\n    text: Coins\n  - range: 2\n    table: gems\n")},
+		"tables/gems.csv":  {Data: []byte("range,text\n1-2,Ruby\n3-4,Pearl\n")},
+		"tables/road.json": {Data: []byte(`{"entries": [{"weight": 1, "table": "road"}]}`)},
+		"tables/notes.txt": {Data: []byte("not a table")},
+	}
+
+	registry, err := LoadTableRegistry(fsys)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	names := registry.Names()
+	if strings.Join(names, ",") != "Loot,gems,road" {
+		t.Errorf("Expected names [Loot gems road], got %v", names)
+	}
+
+	result, err := registry.Roll("Loot")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if text := result.Text(); len(text) != 1 || (text[0] != "Coins" && text[0] != "Ruby" && text[0] != "Pearl") {
+		t.Errorf("Unexpected text %v", text)
+	}
+
+	// A table that references itself is rolled until the maximum depth
+	road, err := registry.Table("road")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if road.Entries()[0].Table != road {
+		t.Errorf("Expected the table to reference itself, got %s", road)
+	}
+
+	if _, err := registry.Table("Missing"); !errors.Is(err, ErrTableNotFound) {
+		t.Errorf("Expected %v, got %v", ErrTableNotFound, err)
+	}
+	if _, err := registry.Roll("Missing"); !errors.Is(err, ErrTableNotFound) {
+		t.Errorf("Expected %v, got %v", ErrTableNotFound, err)
+	}
+
+	// Only the files that match the patterns are loaded
+	registry, err = LoadTableRegistry(fsys, "tables/*.csv", "tables/g*")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if names := registry.Names(); len(names) != 1 || names[0] != "gems" {
+		t.Errorf("Expected names [gems], got %v", names)
+	}
+
+	// Errors include the file
+	fsys["tables/extra.json"] = &fstest.MapFile{Data: []byte(`{"name": "Loot", "entries": [{"weight": 1}]}`)}
+	if _, err := LoadTableRegistry(fsys); !errors.Is(err, ErrTableDuplicate) || !strings.HasPrefix(err.Error(), "tables/") {
+		t.Errorf("Expected %v, got %v", ErrTableDuplicate, err)
+	}
+}
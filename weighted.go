@@ -0,0 +1,271 @@
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rbrabson/dice/mathx"
+)
+
+var ErrInvalidWeight = errors.New("invalid weight")
+
+// StandardDieSides are the number of sides on the dice that are tried, in order, when converting a
+// weighted table into a table rolled on a single die.
+var StandardDieSides = []int{2, 3, 4, 6, 8, 10, 12, 20, 100}
+
+// standardDigitSides are the digit dice, d66 and d666, that are also tried when converting a weighted
+// table into a table rolled on dice.
+var standardDigitSides = [][]int{{6, 6}, {6, 6, 6}}
+
+// WeightedItem is an item in a weighted table, selected with a probability proportional to its weight.
+type WeightedItem[T any] struct {
+	Item   T   // The item that is selected
+	Weight int // The relative weight of the item; an item with a weight of 0 is never selected
+}
+
+// WeightedTable is a random table where each item is selected with a probability proportional to its
+// weight, rather than by a range of values on a dice.
+type WeightedTable[T any] interface {
+	Items() []WeightedItem[T]                    // The items and their weights
+	Total() int                                  // The sum of the weights
+	Probability(index int) float64               // The probability of selecting the item at the index
+	SetWeight(index int, weight int) error       // Changes the weight of the item at the index
+	Sample() T                                   // Selects an item
+	SampleWithoutReplacement(n int) ([]T, error) // Selects n different items
+	Table(name string) Table                     // A table rolled on the smallest dice that gives the same probabilities
+	fmt.Stringer                                 // String representation of the table
+}
+
+// weightedTable is an implementation of the WeightedTable interface. Items are sampled in constant time
+// using the alias method, with the alias tables rebuilt after the weights change.
+type weightedTable[T any] struct {
+	items []WeightedItem[T] // The items and their weights
+	total int               // The sum of the weights
+	prob  []float64         // The probability of keeping each column of the alias table
+	alias []int             // The item selected for each column when it is not kept
+}
+
+// NewWeightedTable creates a weighted table with the items. An error is returned if any weight is
+// negative or if the weights add up to 0.
+func NewWeightedTable[T any](items ...WeightedItem[T]) (WeightedTable[T], error) {
+	wt := &weightedTable[T]{
+		items: make([]WeightedItem[T], 0, len(items)),
+	}
+	for i, item := range items {
+		if item.Weight < 0 {
+			return nil, fmt.Errorf("%w: item %d has weight %d", ErrInvalidWeight, i, item.Weight)
+		}
+		wt.items = append(wt.items, item)
+		wt.total += item.Weight
+	}
+	if wt.total == 0 {
+		return nil, fmt.Errorf("%w: the weights add up to 0", ErrInvalidWeight)
+	}
+
+	return wt, nil
+}
+
+// Items returns a copy of the items and their weights. Use SetWeight to change a weight.
+func (wt *weightedTable[T]) Items() []WeightedItem[T] {
+	items := make([]WeightedItem[T], len(wt.items))
+	copy(items, wt.items)
+	return items
+}
+
+// Total returns the sum of the weights.
+func (wt *weightedTable[T]) Total() int {
+	return wt.total
+}
+
+// Probability returns the probability of selecting the item at the index.
+func (wt *weightedTable[T]) Probability(index int) float64 {
+	if index < 0 || index >= len(wt.items) {
+		return 0
+	}
+	return float64(wt.items[index].Weight) / float64(wt.total)
+}
+
+// SetWeight changes the weight of the item at the index. An error is returned if there is no item at
+// the index, if the weight is negative, or if the weights would add up to 0.
+func (wt *weightedTable[T]) SetWeight(index int, weight int) error {
+	if index < 0 || index >= len(wt.items) {
+		return fmt.Errorf("%w: no item at index %d", ErrInvalidWeight, index)
+	}
+	if weight < 0 {
+		return fmt.Errorf("%w: item %d has weight %d", ErrInvalidWeight, index, weight)
+	}
+	total := wt.total - wt.items[index].Weight + weight
+	if total == 0 {
+		return fmt.Errorf("%w: the weights add up to 0", ErrInvalidWeight)
+	}
+
+	wt.items[index].Weight = weight
+	wt.total = total
+	wt.prob, wt.alias = nil, nil
+	return nil
+}
+
+// Sample selects an item with a probability proportional to its weight.
+func (wt *weightedTable[T]) Sample() T {
+	if wt.prob == nil {
+		wt.buildAlias()
+	}
+	column := rng.Intn(len(wt.items))
+	if rng.Float64() < wt.prob[column] {
+		return wt.items[column].Item
+	}
+	return wt.items[wt.alias[column]].Item
+}
+
+// buildAlias builds the alias tables using Vose's method. Each column holds the scaled probability of
+// its own item and the item that fills the rest of the column.
+func (wt *weightedTable[T]) buildAlias() {
+	n := len(wt.items)
+	wt.prob = make([]float64, n)
+	wt.alias = make([]int, n)
+
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, item := range wt.items {
+		scaled[i] = float64(item.Weight) * float64(n) / float64(wt.total)
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small, large = small[:len(small)-1], large[:len(large)-1]
+
+		wt.prob[s] = scaled[s]
+		wt.alias[s] = l
+		scaled[l] = scaled[l] + scaled[s] - 1
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+
+	// Any remaining columns are full, apart from rounding errors
+	for _, i := range append(small, large...) {
+		wt.prob[i] = 1
+		wt.alias[i] = i
+	}
+}
+
+// SampleWithoutReplacement selects n different items, where each item is selected with a probability
+// proportional to its weight among the items not yet selected. The weights in the table are not
+// changed. An error is returned if there are fewer than n items with a weight greater than 0.
+func (wt *weightedTable[T]) SampleWithoutReplacement(n int) ([]T, error) {
+	weights := make([]int, len(wt.items))
+	available := 0
+	for i, item := range wt.items {
+		weights[i] = item.Weight
+		if item.Weight > 0 {
+			available++
+		}
+	}
+	if n < 0 || n > available {
+		return nil, fmt.Errorf("%w: cannot select %d of %d items", ErrInvalidWeight, n, available)
+	}
+
+	selected := make([]T, 0, n)
+	total := wt.total
+	for range n {
+		value := rng.Intn(total)
+		for i, weight := range weights {
+			if value < weight {
+				selected = append(selected, wt.items[i].Item)
+				total -= weight
+				weights[i] = 0
+				break
+			}
+			value -= weight
+		}
+	}
+
+	return selected, nil
+}
+
+// Table returns a table with the same probabilities as the weighted table. The weights are reduced by
+// their greatest common divisor, and the table is rolled on the dice with the fewest outcomes that is
+// a multiple of the reduced total, chosen from the StandardDieSides and the d66 and d666 digit dice,
+// such as a d66 for a total of 36. If none of them fits, such as for a total of 7 or 101, a single die
+// with as many sides as the reduced total is used. Items with a weight of 0 are not included, and the
+// text of each entry is the item formatted with `fmt.Sprint`.
+func (wt *weightedTable[T]) Table(name string) Table {
+	divisor := 0
+	for _, item := range wt.items {
+		divisor = mathx.GCD(divisor, item.Weight)
+	}
+	reduced := wt.total / divisor
+
+	outcomes := 0
+	for _, s := range StandardDieSides {
+		if s%reduced == 0 {
+			outcomes = s
+			break
+		}
+	}
+	var digits []int
+	for _, sides := range standardDigitSides {
+		n := digitOutcomes(sides)
+		if n%reduced == 0 && (outcomes == 0 || n < outcomes) {
+			outcomes, digits = n, sides
+			break
+		}
+	}
+
+	// The values that can be rolled, in order, each of which is equally likely
+	var d Dice
+	var values []int
+	if digits != nil {
+		dd := &digitDice{
+			sides: append([]int{}, digits...),
+		}
+		d, values = dd, dd.values()
+	} else {
+		outcomes = max(outcomes, reduced)
+		d = NewDice(1, outcomes)
+		values = make([]int, 0, outcomes)
+		for value := 1; value <= outcomes; value++ {
+			values = append(values, value)
+		}
+	}
+	scale := outcomes / reduced
+
+	t := &table{
+		name:    name,
+		dice:    d,
+		entries: make([]TableEntry, 0, len(wt.items)),
+	}
+	next := 0
+	for _, item := range wt.items {
+		if item.Weight == 0 {
+			continue
+		}
+		size := item.Weight / divisor * scale
+		t.entries = append(t.entries, TableEntry{
+			Min:  values[next],
+			Max:  values[next+size-1],
+			Text: fmt.Sprint(item.Item),
+		})
+		next += size
+	}
+
+	return t
+}
+
+// String returns a string representation of the table, such as `Goblin: 3, Orc: 1`.
+func (wt *weightedTable[T]) String() string {
+	items := make([]string, 0, len(wt.items))
+	for _, item := range wt.items {
+		items = append(items, fmt.Sprint(item.Item)+": "+strconv.Itoa(item.Weight))
+	}
+	return strings.Join(items, ", ")
+}
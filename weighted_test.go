@@ -0,0 +1,143 @@
+package dice
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestNewWeightedTable tests validating the weights of a weighted table
+func TestNewWeightedTable(t *testing.T) {
+	if _, err := NewWeightedTable(WeightedItem[string]{"Goblin", -1}); !errors.Is(err, ErrInvalidWeight) {
+		t.Errorf("Expected %v for a negative weight, got %v", ErrInvalidWeight, err)
+	}
+	if _, err := NewWeightedTable(WeightedItem[string]{"Goblin", 0}); !errors.Is(err, ErrInvalidWeight) {
+		t.Errorf("Expected %v for a total weight of 0, got %v", ErrInvalidWeight, err)
+	}
+
+	wt, err := NewWeightedTable(WeightedItem[string]{"Goblin", 3}, WeightedItem[string]{"Orc", 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if wt.Total() != 4 || !almostEqual(wt.Probability(0), 0.75) || wt.Probability(2) != 0 {
+		t.Errorf("Unexpected table %s", wt)
+	}
+	if wt.String() != "Goblin: 3, Orc: 1" {
+		t.Errorf("Unexpected string `%s`", wt)
+	}
+}
+
+// TestWeightedTableSample tests that items are sampled in proportion to their weights
+func TestWeightedTableSample(t *testing.T) {
+	wt, err := NewWeightedTable(
+		WeightedItem[int]{1, 1},
+		WeightedItem[int]{2, 0},
+		WeightedItem[int]{3, 6},
+		WeightedItem[int]{4, 3},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	const samples = 100000
+	counts := make(map[int]int)
+	for range samples {
+		counts[wt.Sample()]++
+	}
+	expected := map[int]float64{1: 0.1, 2: 0, 3: 0.6, 4: 0.3}
+	for item, p := range expected {
+		if got := float64(counts[item]) / samples; got < p-0.01 || got > p+0.01 {
+			t.Errorf("Expected item %d %.2f of the time, got %.3f", item, p, got)
+		}
+	}
+
+	// Changing a weight rebuilds the alias tables
+	if err := wt.SetWeight(1, 10); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := wt.SetWeight(2, 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	counts = make(map[int]int)
+	for range samples {
+		counts[wt.Sample()]++
+	}
+	if counts[3] != 0 || counts[2] < counts[1]*5 {
+		t.Errorf("Unexpected counts after changing weights %v", counts)
+	}
+
+	if err := wt.SetWeight(5, 1); !errors.Is(err, ErrInvalidWeight) {
+		t.Errorf("Expected %v for an invalid index, got %v", ErrInvalidWeight, err)
+	}
+	if err := wt.SetWeight(0, -1); !errors.Is(err, ErrInvalidWeight) {
+		t.Errorf("Expected %v for a negative weight, got %v", ErrInvalidWeight, err)
+	}
+}
+
+// TestWeightedTableSampleWithoutReplacement tests sampling different items
+func TestWeightedTableSampleWithoutReplacement(t *testing.T) {
+	wt, err := NewWeightedTable(
+		WeightedItem[string]{"a", 5},
+		WeightedItem[string]{"b", 0},
+		WeightedItem[string]{"c", 1},
+		WeightedItem[string]{"d", 1},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for range 100 {
+		items, err := wt.SampleWithoutReplacement(3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		seen := make(map[string]bool)
+		for _, item := range items {
+			if seen[item] || item == "b" {
+				t.Fatalf("Unexpected items %v", items)
+			}
+			seen[item] = true
+		}
+	}
+	if _, err := wt.SampleWithoutReplacement(4); !errors.Is(err, ErrInvalidWeight) {
+		t.Errorf("Expected %v, got %v", ErrInvalidWeight, err)
+	}
+	if wt.Items()[0].Weight != 5 {
+		t.Errorf("Expected the weights to be unchanged, got %s", wt)
+	}
+}
+
+// TestWeightedTableToTable tests converting weights to the smallest dice and ranges
+func TestWeightedTableToTable(t *testing.T) {
+	tests := []struct {
+		weights  []int
+		expected string
+	}{
+		{[]int{3, 2, 1}, "Test (1d6)\n1-3: 0\n4-5: 1\n6: 2"},
+		{[]int{6, 4, 2}, "Test (1d6)\n1-3: 0\n4-5: 1\n6: 2"},
+		{[]int{1, 1, 2}, "Test (1d4)\n1: 0\n2: 1\n3-4: 2"},
+		{[]int{1, 4}, "Test (1d10)\n1-2: 0\n3-10: 1"},
+		{[]int{13, 0, 7}, "Test (1d20)\n1-13: 0\n14-20: 2"},
+		{[]int{1, 6}, "Test (1d7)\n1: 0\n2-7: 1"},
+		{[]int{1, 35}, "Test (d66)\n11: 0\n12-66: 1"},
+		{[]int{5, 4}, "Test (d66)\n11-42: 0\n43-66: 1"},
+		{[]int{100, 1}, "Test (1d101)\n1-100: 0\n101: 1"},
+	}
+
+	for _, test := range tests {
+		items := make([]WeightedItem[int], 0, len(test.weights))
+		for i, weight := range test.weights {
+			items = append(items, WeightedItem[int]{i, weight})
+		}
+		wt, err := NewWeightedTable(items...)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		converted := wt.Table("Test")
+		if converted.String() != test.expected {
+			t.Errorf("Expected `%s`, got `%s`", test.expected, converted)
+		}
+		if _, err := validateTable(converted.(*table)); err != nil {
+			t.Errorf("Unexpected error for %v: %v", test.weights, err)
+		}
+	}
+}